@@ -0,0 +1,190 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Agent ID openclaw uses when agents.list is empty
+const defaultAgentID = "main"
+
+// agentInfo describes an agent discovered from openclaw.json or agents/.
+type agentInfo struct {
+	id        string
+	name      string
+	workspace string
+	model     string
+	// source is "config", "directory" or "both"
+	source string
+	// listed is set for agents with an entry in agents.list
+	listed    bool
+	isDefault bool
+}
+
+// discoverAgents merges the agents configured in openclaw.json with the agent
+// state directories under agents/. Agents are returned sorted by ID. A
+// config that fails to parse is reported as an error alongside the agents
+// found on disk.
+func discoverAgents(openclawHome string) ([]agentInfo, error) {
+	loaded, configErr := loadOpenclawConfig(openclawHome)
+	if configErr != nil && os.IsNotExist(configErr) {
+		configErr = nil
+	}
+
+	var cfg *openclawConfig
+	if loaded != nil {
+		cfg = &loaded.config
+	}
+
+	agents, err := discoverAgentsWithConfig(openclawHome, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return agents, configErr
+}
+
+// discoverAgentsWithConfig is discoverAgents for an already loaded config,
+// which may be nil.
+func discoverAgentsWithConfig(openclawHome string, cfg *openclawConfig) ([]agentInfo, error) {
+	agents := make(map[string]*agentInfo)
+
+	if cfg != nil {
+		defaultID := cfg.defaultAgentID()
+		for _, agent := range cfg.Agents.List {
+			if agent.ID == "" {
+				continue
+			}
+
+			workspace := expandHome(agent.Workspace)
+			if workspace == "" {
+				workspace = defaultWorkspace(openclawHome, cfg, agent.ID, agent.ID == defaultID)
+			}
+
+			agents[agent.ID] = &agentInfo{
+				id:        agent.ID,
+				name:      agent.Name,
+				workspace: workspace,
+				model:     cfg.agentModel(agent.ID),
+				source:    "config",
+				listed:    true,
+				isDefault: agent.ID == defaultID,
+			}
+		}
+
+		// Without an explicit list openclaw runs a single implicit agent
+		if len(cfg.Agents.List) == 0 {
+			agents[defaultAgentID] = &agentInfo{
+				id:        defaultAgentID,
+				workspace: defaultWorkspace(openclawHome, cfg, defaultAgentID, true),
+				model:     cfg.Agents.Defaults.Model.Primary,
+				source:    "config",
+				isDefault: true,
+			}
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(openclawHome, "agents"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		id := entry.Name()
+		if agent, ok := agents[id]; ok {
+			agent.source = "both"
+			continue
+		}
+
+		agent := &agentInfo{
+			id:        id,
+			workspace: defaultWorkspace(openclawHome, cfg, id, id == defaultAgentID),
+			source:    "directory",
+			isDefault: cfg == nil && id == defaultAgentID,
+		}
+		if cfg != nil {
+			agent.model = cfg.Agents.Defaults.Model.Primary
+		}
+		agents[id] = agent
+	}
+
+	result := make([]agentInfo, 0, len(agents))
+	for _, agent := range agents {
+		result = append(result, *agent)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+
+	return result, nil
+}
+
+// defaultAgent returns the ID of the default agent among agents, which
+// openclaw assigns to sessions and cron jobs without an explicit agent.
+func defaultAgent(agents []agentInfo) string {
+	for _, agent := range agents {
+		if agent.isDefault {
+			return agent.id
+		}
+	}
+
+	return defaultAgentID
+}
+
+// agentModel returns the primary model of an agent, falling back to the
+// default model.
+func (c *openclawConfig) agentModel(id string) string {
+	for _, agent := range c.Agents.List {
+		if agent.ID == id && agent.Model.Primary != "" {
+			return agent.Model.Primary
+		}
+	}
+
+	return c.Agents.Defaults.Model.Primary
+}
+
+// defaultAgentID returns the agent marked default in agents.list, falling
+// back to the first entry and then to "main".
+func (c *openclawConfig) defaultAgentID() string {
+	for _, agent := range c.Agents.List {
+		if agent.Default && agent.ID != "" {
+			return agent.ID
+		}
+	}
+
+	if len(c.Agents.List) > 0 && c.Agents.List[0].ID != "" {
+		return c.Agents.List[0].ID
+	}
+
+	return defaultAgentID
+}
+
+// defaultWorkspace returns the workspace openclaw uses for an agent without
+// an explicit workspace: agents.defaults.workspace (or ~/.openclaw/workspace)
+// for the default agent and ~/.openclaw/workspace-<id> for the others.
+func defaultWorkspace(openclawHome string, cfg *openclawConfig, id string, isDefault bool) string {
+	if !isDefault {
+		return filepath.Join(openclawHome, "workspace-"+id)
+	}
+
+	if cfg != nil && cfg.Agents.Defaults.Workspace != "" {
+		return expandHome(cfg.Agents.Defaults.Workspace)
+	}
+
+	return filepath.Join(openclawHome, "workspace")
+}
+
+// expandHome expands a leading "~" to the user's home directory.
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		return filepath.Join(os.Getenv("HOME"), path[1:])
+	}
+
+	return path
+}
@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverAgentsWithConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		list        []configAgent
+		noConfig    bool
+		dirs        []string
+		wantIDs     []string
+		wantDefault string
+	}{
+		{
+			name:        "no config",
+			noConfig:    true,
+			dirs:        []string{"main", "work"},
+			wantIDs:     []string{"main", "work"},
+			wantDefault: "main",
+		},
+		{
+			name:        "implicit default agent",
+			dirs:        []string{"work"},
+			wantIDs:     []string{"main", "work"},
+			wantDefault: "main",
+		},
+		{
+			name:        "explicit default",
+			list:        []configAgent{{ID: "ops"}, {ID: "home", Default: true}},
+			dirs:        []string{"home", "stale"},
+			wantIDs:     []string{"home", "ops", "stale"},
+			wantDefault: "home",
+		},
+		{
+			name:        "first entry is default",
+			list:        []configAgent{{ID: "ops"}, {ID: "home"}},
+			wantIDs:     []string{"home", "ops"},
+			wantDefault: "ops",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			for _, dir := range tt.dirs {
+				if err := os.MkdirAll(filepath.Join(home, "agents", dir), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var cfg *openclawConfig
+			if !tt.noConfig {
+				cfg = &openclawConfig{}
+				cfg.Agents.List = tt.list
+			}
+
+			agents, err := discoverAgentsWithConfig(home, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []string
+			for _, agent := range agents {
+				ids = append(ids, agent.id)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("agent IDs = %v, want %v", ids, tt.wantIDs)
+			}
+			if got := defaultAgent(agents); got != tt.wantDefault {
+				t.Errorf("defaultAgent() = %q, want %q", got, tt.wantDefault)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// Default per-file character limit for bootstrap files injected into the
+// system prompt (agents.defaults.bootstrapMaxChars)
+const defaultBootstrapMaxChars = 20000
+
+// bootstrapFiles are the workspace files openclaw injects into the system
+// prompt, each with the alternative names it accepts.
+var bootstrapFiles = [][]string{
+	{"AGENTS.md"},
+	{"SOUL.md"},
+	{"TOOLS.md"},
+	{"IDENTITY.md"},
+	{"USER.md"},
+	{"HEARTBEAT.md"},
+	{"BOOTSTRAP.md"},
+	{"MEMORY.md", "memory.md"},
+}
+
+// bootstrapFile is a bootstrap file and its share of the prompt budget.
+type bootstrapFile struct {
+	name  string
+	chars float64
+	// tokens counts the injected content, after truncation
+	tokens    float64
+	truncated bool
+}
+
+// scanBootstrapFiles measures the bootstrap files present in dir against the
+// per-file character limit maxChars, counting tokens with encoding.
+func scanBootstrapFiles(ctx context.Context, dir string, maxChars int, tok *Tokenizer, encoding string) ([]bootstrapFile, error) {
+	var files []bootstrapFile
+
+	for _, names := range bootstrapFiles {
+		if err := ctx.Err(); err != nil {
+			return files, err
+		}
+
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			info, err := os.Stat(path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return files, err
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return files, err
+			}
+
+			tokens, err := tok.countData(path, info, data, encoding, maxChars)
+			if err != nil {
+				return files, err
+			}
+
+			chars := utf8.RuneCount(data)
+			files = append(files, bootstrapFile{
+				name:      name,
+				chars:     float64(chars),
+				tokens:    tokens,
+				truncated: chars > maxChars,
+			})
+			break
+		}
+	}
+
+	return files, nil
+}
+
+// bootstrapMaxChars returns the configured per-file bootstrap character limit.
+func (c *openclawConfig) bootstrapMaxChars() int {
+	if c.Agents.Defaults.BootstrapMaxChars > 0 {
+		return c.Agents.Defaults.BootstrapMaxChars
+	}
+
+	return defaultBootstrapMaxChars
+}
@@ -0,0 +1,44 @@
+package collector
+
+import (
+	"runtime"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BuildInfoCollector exposes the exporter's own version information.
+type BuildInfoCollector struct {
+	version string
+	commit  string
+
+	buildInfo *prometheus.Desc
+}
+
+// NewBuildInfoCollector creates a BuildInfoCollector for the version and
+// commit injected at build time.
+func NewBuildInfoCollector(version, commit string) *BuildInfoCollector {
+	return &BuildInfoCollector{
+		version: version,
+		commit:  commit,
+		buildInfo: prometheus.NewDesc(
+			"openclaw_exporter_build_info",
+			"Exporter build information",
+			[]string{"version", "commit", "go_version"}, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *BuildInfoCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.buildInfo
+}
+
+// Collect implements prometheus.Collector.
+func (c *BuildInfoCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		c.buildInfo,
+		prometheus.GaugeValue,
+		1,
+		c.version, c.commit, runtime.Version(),
+	)
+}
@@ -1,12 +1,11 @@
 package collector
 
 import (
-	"bufio"
 	"context"
 	"log"
 	"os"
 	"path/filepath"
-	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,42 +13,146 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-// Default system skills directory (openclaw npm package location)
-const defaultSystemSkillsDir = "/opt/homebrew/lib/node_modules/openclaw/skills"
-
 const (
 	defaultScanInterval = 30 * time.Second
 	defaultScanTimeout  = 10 * time.Second
 )
 
 type fileStat struct {
-	name  string
-	size  float64
-	mtime float64
+	name   string
+	size   float64
+	mtime  float64
+	tokens float64
+
+	// Content change tracking, from the collector's file histories
+	hash              string
+	contentChanges    float64
+	lastContentChange float64
 }
 
-type scrapeSnapshot struct {
+// workspaceSnapshot holds the scan results of a single workspace.
+type workspaceSnapshot struct {
+	workspace       Workspace
+	model           string
+	encoding        string
 	fileStats       []fileStat
 	workspaceExists map[string]float64
 	contextLength   float64
-	skillsCount     float64
-	agentsCount     float64
-	memoryFiles     float64
-	scrapeSuccess   float64
+	contextTokens   float64
+	contextFiles    []fileStat
+	// contextStatErrors accumulates across scans
+	contextStatErrors float64
+	skills            []skillInfo
+	memory            memoryStats
+	memoryDocument    memoryDocument
+	bootstrapFiles    []bootstrapFile
+	git               gitStatus
+	identity          agentIdentity
+	onboarding        onboardingState
+	// secrets is nil when secret scanning is disabled
+	secrets []fileSecrets
+}
+
+type scrapeSnapshot struct {
+	workspaces    []workspaceSnapshot
+	agents        []agentInfo
+	scrapeSuccess float64
+
+	// systemSkillsDir is empty when no system skills directory was found
+	systemSkillsDir    string
+	systemSkillsMethod string
+
+	bootstrapMaxChars int
+
+	install      openclawInstall
+	installFound bool
+	// configVersion is the openclaw version that last wrote openclaw.json
+	configVersion string
 }
 
-// OpenclawCollector collects metrics from openclaw data directory.
+// sharedScan holds the state loaded once per refresh and shared by all
+// workspace scans. It must not be modified by them.
+type sharedScan struct {
+	skills []skillInfo
+	// config is nil when openclaw.json is missing or unreadable
+	config *loadedConfig
+
+	bootstrapMaxChars int
+	// templates is nil when the openclaw install ships no workspace templates
+	templates map[string]string
+}
+
+// OpenclawCollector collects metrics from openclaw workspace directories.
 type OpenclawCollector struct {
-	dir string
-	mu  sync.RWMutex
+	workspaces   []Workspace
+	openclawHome string
+	location     *time.Location
+	memoryWindow int
+	tokenizer    *Tokenizer
+	fileRules    []FileRule
+	secrets      *SecretScanner
+	fileHistory  *fileHistories
+	gitStatus    *gitStatusCache
+	mu           sync.RWMutex
 
 	fileSize         *prometheus.Desc
 	fileMtime        *prometheus.Desc
+	fileTokens       *prometheus.Desc
+	fileChanges      *prometheus.Desc
+	fileLastChange   *prometheus.Desc
+	contextTokens    *prometheus.Desc
+	contextFileSize  *prometheus.Desc
+	contextFileMtime *prometheus.Desc
+	contextFileToks  *prometheus.Desc
+	contextFiles     *prometheus.Desc
+	contextErrors    *prometheus.Desc
+	tokenizerInfo    *prometheus.Desc
 	contextLength    *prometheus.Desc
 	skillsCount      *prometheus.Desc
+	skillInfo        *prometheus.Desc
+	skillsBySource   *prometheus.Desc
+	skillEffective   *prometheus.Desc
+	skillShadowed    *prometheus.Desc
+	skillsShadowed   *prometheus.Desc
+	skillsDuplicated *prometheus.Desc
+	skillValid       *prometheus.Desc
+	skillsInvalid    *prometheus.Desc
+	skillEligible    *prometheus.Desc
+	skillsEligible   *prometheus.Desc
 	agentsCount      *prometheus.Desc
+	agentInfo        *prometheus.Desc
+	agentIdentity    *prometheus.Desc
+	systemSkillsDir  *prometheus.Desc
+	installInfo      *prometheus.Desc
 	workspaceFiles   *prometheus.Desc
+	requiredMissing  *prometheus.Desc
+	onboarding       *prometheus.Desc
+	templateFile     *prometheus.Desc
+	fileSecrets      *prometheus.Desc
+	secretsTotal     *prometheus.Desc
+	gitRepository    *prometheus.Desc
+	gitBranch        *prometheus.Desc
+	gitHeadTime      *prometheus.Desc
+	gitChanged       *prometheus.Desc
+	gitUntracked     *prometheus.Desc
+	gitAhead         *prometheus.Desc
+	gitBehind        *prometheus.Desc
 	memoryFilesCount *prometheus.Desc
+	memoryBytes      *prometheus.Desc
+	memoryFileSize   *prometheus.Desc
+	memoryLatest     *prometheus.Desc
+	memoryDaysSince  *prometheus.Desc
+	memoryMissing    *prometheus.Desc
+	memoryNonDated   *prometheus.Desc
+	memoryMdSections *prometheus.Desc
+	memoryMdEntries  *prometheus.Desc
+	memoryMdWords    *prometheus.Desc
+	memoryMdBytes    *prometheus.Desc
+	bootstrapChars   *prometheus.Desc
+	bootstrapTokens  *prometheus.Desc
+	bootstrapTrunc   *prometheus.Desc
+	bootstrapTotal   *prometheus.Desc
+	bootstrapMax     *prometheus.Desc
 	scrapeSuccess    *prometheus.Desc
 	scanDuration     *prometheus.Desc
 	scanErrors       *prometheus.Desc
@@ -62,43 +165,342 @@ type OpenclawCollector struct {
 	scanErrorsTotal  uint64
 }
 
-// NewOpenclawCollector creates a new OpenclawCollector.
-func NewOpenclawCollector(dir string) *OpenclawCollector {
+// OpenclawOptions configures the workspace scans of an OpenclawCollector.
+// Zero values select the defaults.
+type OpenclawOptions struct {
+	// Location dates daily memory files (default: time.Local)
+	Location *time.Location
+	// MemoryWindowDays is the number of days, ending today, checked for
+	// missing daily memory files (default: 7)
+	MemoryWindowDays int
+	// Tokenizer counts tokens of workspace files (default: built-in
+	// model encodings)
+	Tokenizer *Tokenizer
+	// FileRules select the monitored workspace files (default: the files
+	// openclaw creates)
+	FileRules []FileRule
+	// Secrets scans workspace files for leaked secrets (default: disabled)
+	Secrets *SecretScanner
+}
+
+// NewOpenclawCollector creates a new OpenclawCollector for the given agent
+// workspaces. Agents are discovered from the openclaw home directory.
+func NewOpenclawCollector(workspaces []Workspace, openclawHome string, opts OpenclawOptions) *OpenclawCollector {
+	if openclawHome == "" {
+		openclawHome = os.Getenv("HOME") + defaultOpenclawHome
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.MemoryWindowDays <= 0 {
+		opts.MemoryWindowDays = defaultMemoryWindowDays
+	}
+	if len(opts.FileRules) == 0 {
+		opts.FileRules = defaultFileRules
+	}
+	if opts.Tokenizer == nil {
+		// The zero config only uses built-in encodings and cannot fail
+		opts.Tokenizer, _ = NewTokenizer(TokenizerConfig{})
+	}
+
 	c := &OpenclawCollector{
-		dir: dir,
+		workspaces:   workspaces,
+		openclawHome: openclawHome,
+		location:     opts.Location,
+		memoryWindow: opts.MemoryWindowDays,
+		tokenizer:    opts.Tokenizer,
+		fileRules:    opts.FileRules,
+		secrets:      opts.Secrets,
+		fileHistory:  newFileHistories(),
+		gitStatus:    newGitStatusCache(),
 		fileSize: prometheus.NewDesc(
 			"openclaw_file_size_bytes",
 			"Size of openclaw files in bytes",
-			[]string{"file"}, nil,
+			[]string{"agent", "workspace", "file"}, nil,
 		),
 		fileMtime: prometheus.NewDesc(
 			"openclaw_file_mtime_seconds",
 			"Last modification time of openclaw files in seconds since epoch",
-			[]string{"file"}, nil,
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		fileTokens: prometheus.NewDesc(
+			"openclaw_file_tokens",
+			"Number of tokens in openclaw files with the workspace's tokenizer encoding",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		fileChanges: prometheus.NewDesc(
+			"openclaw_file_content_changes_total",
+			"Number of content changes of openclaw files detected by hashing since the exporter started",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		fileLastChange: prometheus.NewDesc(
+			"openclaw_file_content_last_change_timestamp_seconds",
+			"Modification time of the last content change of openclaw files in seconds since epoch",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		contextTokens: prometheus.NewDesc(
+			"openclaw_context_tokens_total",
+			"Total number of tokens in context files with the workspace's tokenizer encoding",
+			[]string{"agent", "workspace"}, nil,
+		),
+		contextFileSize: prometheus.NewDesc(
+			"openclaw_context_file_size_bytes",
+			"Size of a context*.md file in bytes",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		contextFileMtime: prometheus.NewDesc(
+			"openclaw_context_file_mtime_seconds",
+			"Last modification time of a context*.md file in seconds since epoch",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		contextFileToks: prometheus.NewDesc(
+			"openclaw_context_file_tokens",
+			"Number of tokens in a context*.md file with the workspace's tokenizer encoding",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		contextFiles: prometheus.NewDesc(
+			"openclaw_context_files_total",
+			"Number of context*.md files in the workspace",
+			[]string{"agent", "workspace"}, nil,
+		),
+		contextErrors: prometheus.NewDesc(
+			"openclaw_context_stat_errors_total",
+			"Number of times a context*.md file could not be stat'ed or read",
+			[]string{"agent", "workspace"}, nil,
+		),
+		tokenizerInfo: prometheus.NewDesc(
+			"openclaw_tokenizer_info",
+			"Tokenizer encoding used for the workspace, chosen by the agent's model",
+			[]string{"agent", "workspace", "model", "encoding"}, nil,
 		),
 		contextLength: prometheus.NewDesc(
 			"openclaw_context_length_total",
 			"Total size of context files in bytes (includes conversation history, tool results, and attachments)",
-			nil, nil,
+			[]string{"agent", "workspace"}, nil,
 		),
 		skillsCount: prometheus.NewDesc(
 			"openclaw_skills_total",
 			"Total number of skills in workspace and managed directories",
-			nil, nil,
+			[]string{"agent", "workspace"}, nil,
+		),
+		skillInfo: prometheus.NewDesc(
+			"openclaw_skill_info",
+			"Skill available to the agent (source is legacy, workspace, user or system)",
+			[]string{"agent", "workspace", "name", "source", "dir"}, nil,
+		),
+		skillsBySource: prometheus.NewDesc(
+			"openclaw_skills_by_source_total",
+			"Number of skills per skill source",
+			[]string{"agent", "workspace", "source"}, nil,
+		),
+		skillEffective: prometheus.NewDesc(
+			"openclaw_skill_effective",
+			"Whether this copy of a skill is the one loaded by the agent after source precedence",
+			[]string{"agent", "workspace", "name", "source", "dir"}, nil,
+		),
+		skillShadowed: prometheus.NewDesc(
+			"openclaw_skill_shadowed",
+			"Skill copy overridden by a copy of the same name from shadowed_by (same source = duplicate)",
+			[]string{"agent", "workspace", "name", "source", "dir", "shadowed_by"}, nil,
+		),
+		skillsShadowed: prometheus.NewDesc(
+			"openclaw_skills_shadowed_total",
+			"Number of skill copies overridden by a higher-precedence source",
+			[]string{"agent", "workspace"}, nil,
+		),
+		skillsDuplicated: prometheus.NewDesc(
+			"openclaw_skills_duplicated_total",
+			"Number of skill copies overridden by another copy in the same source",
+			[]string{"agent", "workspace"}, nil,
+		),
+		skillValid: prometheus.NewDesc(
+			"openclaw_skill_valid",
+			"Whether the skill's SKILL.md frontmatter is valid (reason is none or the first failure)",
+			[]string{"agent", "workspace", "name", "source", "dir", "reason"}, nil,
+		),
+		skillsInvalid: prometheus.NewDesc(
+			"openclaw_skills_invalid_total",
+			"Number of skills whose SKILL.md frontmatter failed validation",
+			[]string{"agent", "workspace"}, nil,
+		),
+		skillEligible: prometheus.NewDesc(
+			"openclaw_skill_eligible",
+			"Whether the skill's required binaries, env vars, config and OS are satisfied (missing is none or the first unmet requirement)",
+			[]string{"agent", "workspace", "name", "source", "dir", "missing"}, nil,
+		),
+		skillsEligible: prometheus.NewDesc(
+			"openclaw_skills_eligible_total",
+			"Number of effective skills whose requirements are satisfied",
+			[]string{"agent", "workspace"}, nil,
 		),
 		agentsCount: prometheus.NewDesc(
 			"openclaw_agents_total",
-			"Total number of agents (counts agent definitions in agent.md, if present)",
+			"Total number of agents configured in openclaw.json or present in agents/",
 			nil, nil,
 		),
+		agentInfo: prometheus.NewDesc(
+			"openclaw_agent_info",
+			"Agent information (source is config, directory or both)",
+			[]string{"agent", "workspace", "model", "source"}, nil,
+		),
+		agentIdentity: prometheus.NewDesc(
+			"openclaw_agent_identity_info",
+			"Agent identity from the workspace IDENTITY.md (creature also accepts a Role field)",
+			[]string{"agent", "workspace", "name", "creature", "vibe", "emoji"}, nil,
+		),
+		systemSkillsDir: prometheus.NewDesc(
+			"openclaw_system_skills_dir_info",
+			"Resolved system skills directory (method is env, path, npm_prefix, nvm, pnpm, bun, system or none)",
+			[]string{"path", "method"}, nil,
+		),
+		installInfo: prometheus.NewDesc(
+			"openclaw_install_info",
+			"Installed openclaw package version and the version that last wrote openclaw.json",
+			[]string{"version", "config_version", "path", "method"}, nil,
+		),
 		workspaceFiles: prometheus.NewDesc(
 			"openclaw_workspace_file_exists",
-			"Whether workspace files exist (AGENTS.md, SOUL.md, TOOLS.md, IDENTITY.md, USER.md, HEARTBEAT.md, BOOTSTRAP.md, MEMORY.md)",
-			[]string{"file"}, nil,
+			"Whether a file matching the file rule exists in the workspace",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		requiredMissing: prometheus.NewDesc(
+			"openclaw_workspace_required_file_missing",
+			"Whether no file matches a required file rule",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		fileSecrets: prometheus.NewDesc(
+			"openclaw_file_secrets_suspected",
+			"Number of suspected secrets in the workspace file by pattern (only when secret scanning is enabled)",
+			[]string{"agent", "workspace", "file", "pattern"}, nil,
+		),
+		secretsTotal: prometheus.NewDesc(
+			"openclaw_workspace_secrets_suspected_total",
+			"Number of suspected secrets in all scanned workspace files (only when secret scanning is enabled)",
+			[]string{"agent", "workspace"}, nil,
+		),
+		onboarding: prometheus.NewDesc(
+			"openclaw_workspace_onboarding_status",
+			"First-run onboarding status of the workspace (value=1 for the current status: not_started, in_progress or complete)",
+			[]string{"agent", "workspace", "status"}, nil,
+		),
+		templateFile: prometheus.NewDesc(
+			"openclaw_workspace_file_template_unchanged",
+			"Whether the workspace file still matches the default template of the openclaw install",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		gitRepository: prometheus.NewDesc(
+			"openclaw_workspace_git_repository",
+			"Whether the workspace is the root of a git repository",
+			[]string{"agent", "workspace"}, nil,
+		),
+		gitBranch: prometheus.NewDesc(
+			"openclaw_workspace_git_branch_info",
+			"Checked out branch of the workspace repository (HEAD when detached) and its upstream",
+			[]string{"agent", "workspace", "branch", "upstream"}, nil,
+		),
+		gitHeadTime: prometheus.NewDesc(
+			"openclaw_workspace_git_head_commit_timestamp_seconds",
+			"Commit time of HEAD in seconds since epoch",
+			[]string{"agent", "workspace"}, nil,
+		),
+		gitChanged: prometheus.NewDesc(
+			"openclaw_workspace_git_changed_files",
+			"Number of tracked files with staged or unstaged changes",
+			[]string{"agent", "workspace"}, nil,
+		),
+		gitUntracked: prometheus.NewDesc(
+			"openclaw_workspace_git_untracked_files",
+			"Number of untracked files not ignored by .gitignore",
+			[]string{"agent", "workspace"}, nil,
+		),
+		gitAhead: prometheus.NewDesc(
+			"openclaw_workspace_git_ahead_commits",
+			"Number of commits on the branch not on its upstream",
+			[]string{"agent", "workspace"}, nil,
+		),
+		gitBehind: prometheus.NewDesc(
+			"openclaw_workspace_git_behind_commits",
+			"Number of commits on the upstream not on the branch",
+			[]string{"agent", "workspace"}, nil,
 		),
 		memoryFilesCount: prometheus.NewDesc(
 			"openclaw_memory_files_total",
 			"Total number of daily memory files in memory/ directory",
+			[]string{"agent", "workspace"}, nil,
+		),
+		memoryBytes: prometheus.NewDesc(
+			"openclaw_memory_bytes_total",
+			"Total size of memory files in memory/ directory in bytes",
+			[]string{"agent", "workspace"}, nil,
+		),
+		memoryFileSize: prometheus.NewDesc(
+			"openclaw_memory_file_size_bytes",
+			"Size of a memory file in memory/ directory in bytes",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		memoryLatest: prometheus.NewDesc(
+			"openclaw_memory_latest_date_timestamp_seconds",
+			"Date of the newest daily memory file (midnight, in seconds since epoch)",
+			[]string{"agent", "workspace"}, nil,
+		),
+		memoryDaysSince: prometheus.NewDesc(
+			"openclaw_memory_days_since_last_entry",
+			"Calendar days since the date of the newest daily memory file",
+			[]string{"agent", "workspace"}, nil,
+		),
+		memoryMissing: prometheus.NewDesc(
+			"openclaw_memory_missing_days",
+			"Days without a daily memory file in the window ending today",
+			[]string{"agent", "workspace", "window_days"}, nil,
+		),
+		memoryNonDated: prometheus.NewDesc(
+			"openclaw_memory_nonconforming_files_total",
+			"Memory files whose names do not follow YYYY-MM-DD.md or YYYY-MM-DD-<slug>.md",
+			[]string{"agent", "workspace"}, nil,
+		),
+		memoryMdSections: prometheus.NewDesc(
+			"openclaw_memory_md_sections",
+			"Number of markdown headings in MEMORY.md",
+			[]string{"agent", "workspace"}, nil,
+		),
+		memoryMdEntries: prometheus.NewDesc(
+			"openclaw_memory_md_entries",
+			"Number of list entries in MEMORY.md",
+			[]string{"agent", "workspace"}, nil,
+		),
+		memoryMdWords: prometheus.NewDesc(
+			"openclaw_memory_md_words",
+			"Number of words in MEMORY.md",
+			[]string{"agent", "workspace"}, nil,
+		),
+		memoryMdBytes: prometheus.NewDesc(
+			"openclaw_memory_md_bytes",
+			"Size of MEMORY.md in bytes",
+			[]string{"agent", "workspace"}, nil,
+		),
+		bootstrapChars: prometheus.NewDesc(
+			"openclaw_bootstrap_file_chars",
+			"Number of characters in a bootstrap file injected into the system prompt",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		bootstrapTokens: prometheus.NewDesc(
+			"openclaw_bootstrap_file_tokens_estimate",
+			"Estimated tokens a bootstrap file adds to the system prompt after truncation",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		bootstrapTrunc: prometheus.NewDesc(
+			"openclaw_bootstrap_file_truncated",
+			"Whether a bootstrap file exceeds the per-file character limit and is truncated",
+			[]string{"agent", "workspace", "file"}, nil,
+		),
+		bootstrapTotal: prometheus.NewDesc(
+			"openclaw_bootstrap_tokens_estimate_total",
+			"Estimated tokens all bootstrap files add to the system prompt",
+			[]string{"agent", "workspace"}, nil,
+		),
+		bootstrapMax: prometheus.NewDesc(
+			"openclaw_bootstrap_max_chars",
+			"Per-file bootstrap character limit (agents.defaults.bootstrapMaxChars)",
 			nil, nil,
 		),
 		scrapeSuccess: prometheus.NewDesc(
@@ -120,8 +522,9 @@ func NewOpenclawCollector(dir string) *OpenclawCollector {
 		scanTimeout:      defaultScanTimeout,
 		latencyCollector: NewResponseLatencyCollector(),
 		snapshot: scrapeSnapshot{
-			workspaceExists: make(map[string]float64),
-			scrapeSuccess:   0,
+			scrapeSuccess:      0,
+			systemSkillsMethod: "none",
+			bootstrapMaxChars:  defaultBootstrapMaxChars,
 		},
 	}
 
@@ -150,34 +553,68 @@ func (c *OpenclawCollector) refreshSnapshot() {
 
 	start := time.Now()
 	snapshot := scrapeSnapshot{
-		workspaceExists: make(map[string]float64),
+		workspaces: make([]workspaceSnapshot, len(c.workspaces)),
 	}
 
 	errorCount := 0
 
-	if err := c.collectFileMetrics(ctx, &snapshot); err != nil {
-		log.Printf("Error collecting file metrics: %v", err)
+	shared := &sharedScan{bootstrapMaxChars: defaultBootstrapMaxChars}
+
+	snapshot.install, snapshot.installFound = resolveOpenclawInstall()
+	snapshot.systemSkillsDir, snapshot.systemSkillsMethod = resolveSystemSkillsDir(snapshot.install, snapshot.installFound)
+
+	sharedSkills, err := c.scanSharedSkills(ctx, snapshot.systemSkillsDir)
+	if err != nil {
+		log.Printf("Error collecting shared skills metrics: %v", err)
 		errorCount++
 	}
+	shared.skills = sharedSkills
 
-	if err := c.collectWorkspaceFileMetrics(ctx, &snapshot); err != nil {
-		log.Printf("Error collecting workspace file metrics: %v", err)
-		errorCount++
+	if snapshot.installFound {
+		templates, err := loadWorkspaceTemplates(snapshot.install.dir)
+		if err != nil {
+			log.Printf("Error loading workspace templates: %v", err)
+			errorCount++
+		}
+		shared.templates = templates
 	}
 
-	if err := c.collectContextMetrics(ctx, &snapshot); err != nil {
-		log.Printf("Error collecting context metrics: %v", err)
-		errorCount++
+	// Config errors are reported by the agents scan
+	if loaded, err := loadOpenclawConfig(c.openclawHome); err == nil {
+		shared.config = loaded
+		snapshot.configVersion = loaded.config.Meta.LastTouchedVersion
+		shared.bootstrapMaxChars = loaded.config.bootstrapMaxChars()
 	}
+	snapshot.bootstrapMaxChars = shared.bootstrapMaxChars
 
-	if err := c.collectMemoryMetrics(ctx, &snapshot); err != nil {
-		log.Printf("Error collecting memory metrics: %v", err)
-		errorCount++
+	// Scans carry state over from the previous snapshot
+	c.mu.RLock()
+	previous := c.snapshot.workspaces
+	c.mu.RUnlock()
+
+	// Scan workspaces concurrently; each goroutine owns its slot
+	errorCounts := make([]int, len(c.workspaces))
+	var wg sync.WaitGroup
+	for i, ws := range c.workspaces {
+		var prev *workspaceSnapshot
+		if i < len(previous) {
+			prev = &previous[i]
+		}
+
+		wg.Add(1)
+		go func(i int, ws Workspace) {
+			defer wg.Done()
+			snapshot.workspaces[i], errorCounts[i] = c.scanWorkspace(ctx, ws, shared, prev)
+		}(i, ws)
+	}
+	wg.Wait()
+	c.tokenizer.prune()
+	if c.secrets != nil {
+		c.secrets.prune()
 	}
 
-	if err := c.collectSkillsMetrics(ctx, &snapshot); err != nil {
-		log.Printf("Error collecting skills metrics: %v", err)
-		errorCount++
+	for _, count := range errorCounts {
+		errorCount += count
 	}
 
 	if err := c.collectAgentsMetrics(ctx, &snapshot); err != nil {
@@ -202,15 +639,185 @@ func (c *OpenclawCollector) refreshSnapshot() {
 	c.latencyCollector.ObserveLatency("openclaw_scan", duration)
 }
 
+// scanWorkspace collects the metrics of a single workspace and returns the
+// number of errors encountered. previous is the workspace's last snapshot,
+// or nil on the first scan.
+func (c *OpenclawCollector) scanWorkspace(ctx context.Context, ws Workspace, shared *sharedScan, previous *workspaceSnapshot) (workspaceSnapshot, int) {
+	snapshot := workspaceSnapshot{
+		workspace:       ws,
+		workspaceExists: make(map[string]float64),
+	}
+
+	if shared.config != nil {
+		snapshot.model = shared.config.config.agentModel(ws.Agent)
+	}
+	snapshot.encoding = c.tokenizer.encodingFor(snapshot.model)
+
+	errorCount := 0
+
+	if err := c.collectFileMetrics(ctx, ws, &snapshot); err != nil {
+		log.Printf("Error collecting file metrics for %s: %v", ws.Dir, err)
+		errorCount++
+	}
+
+	if err := c.collectContextMetrics(ctx, ws, previous, &snapshot); err != nil {
+		log.Printf("Error collecting context metrics for %s: %v", ws.Dir, err)
+		errorCount++
+	}
+
+	if err := c.collectMemoryMetrics(ctx, ws, &snapshot); err != nil {
+		log.Printf("Error collecting memory metrics for %s: %v", ws.Dir, err)
+		errorCount++
+	}
+
+	if err := c.collectSecretMetrics(ctx, ws, &snapshot); err != nil {
+		log.Printf("Error collecting secret metrics for %s: %v", ws.Dir, err)
+		errorCount++
+	}
+
+	if err := c.collectBootstrapMetrics(ctx, ws, shared, &snapshot); err != nil {
+		log.Printf("Error collecting bootstrap metrics for %s: %v", ws.Dir, err)
+		errorCount++
+	}
+
+	if err := c.collectIdentityMetrics(ctx, ws, &snapshot); err != nil {
+		log.Printf("Error collecting identity metrics for %s: %v", ws.Dir, err)
+		errorCount++
+	}
+
+	if err := c.collectOnboardingMetrics(ctx, ws, shared, &snapshot); err != nil {
+		log.Printf("Error collecting onboarding metrics for %s: %v", ws.Dir, err)
+		errorCount++
+	}
+
+	if err := c.collectGitMetrics(ctx, ws, &snapshot); err != nil {
+		log.Printf("Error collecting git metrics for %s: %v", ws.Dir, err)
+		errorCount++
+	}
+
+	if err := c.collectSkillsMetrics(ctx, ws, shared, &snapshot); err != nil {
+		log.Printf("Error collecting skills metrics for %s: %v", ws.Dir, err)
+		errorCount++
+	}
+
+	return snapshot, errorCount
+}
+
+// EffectiveSkills implements SkillInventory, returning the names of the skills
+// the agent loads across its workspaces as of the last scan.
+func (c *OpenclawCollector) EffectiveSkills(agent string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var skills []string
+	for _, ws := range c.snapshot.workspaces {
+		if ws.workspace.Agent != agent {
+			continue
+		}
+		for _, skill := range ws.skills {
+			if skill.effective {
+				skills = append(skills, skill.name)
+			}
+		}
+	}
+
+	return skills
+}
+
+// SkillForPath implements SkillInventory. A path belongs to a skill if it is
+// the skill's directory or lies below it; "~" is expanded and relative paths
+// are resolved against each of the agent's workspaces.
+func (c *OpenclawCollector) SkillForPath(agent, path string) (string, bool) {
+	path = expandHome(path)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, ws := range c.snapshot.workspaces {
+		if ws.workspace.Agent != agent {
+			continue
+		}
+
+		resolved := path
+		if !filepath.IsAbs(resolved) {
+			resolved = filepath.Join(ws.workspace.Dir, resolved)
+		}
+		resolved = filepath.Clean(resolved)
+
+		for _, skill := range ws.skills {
+			// Legacy skills are sections of a single file
+			if skill.source == skillSourceLegacy || skill.dir == "" {
+				continue
+			}
+			if resolved == skill.dir || strings.HasPrefix(resolved, skill.dir+string(filepath.Separator)) {
+				return skill.name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
 // Describe implements prometheus.Collector.
 func (c *OpenclawCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.fileSize
 	ch <- c.fileMtime
+	ch <- c.fileTokens
+	ch <- c.fileChanges
+	ch <- c.fileLastChange
+	ch <- c.contextTokens
+	ch <- c.contextFileSize
+	ch <- c.contextFileMtime
+	ch <- c.contextFileToks
+	ch <- c.contextFiles
+	ch <- c.contextErrors
+	ch <- c.tokenizerInfo
 	ch <- c.contextLength
 	ch <- c.skillsCount
+	ch <- c.skillInfo
+	ch <- c.skillsBySource
+	ch <- c.skillEffective
+	ch <- c.skillShadowed
+	ch <- c.skillsShadowed
+	ch <- c.skillsDuplicated
+	ch <- c.skillValid
+	ch <- c.skillsInvalid
+	ch <- c.skillEligible
+	ch <- c.skillsEligible
 	ch <- c.agentsCount
+	ch <- c.agentInfo
+	ch <- c.agentIdentity
+	ch <- c.systemSkillsDir
+	ch <- c.installInfo
 	ch <- c.workspaceFiles
+	ch <- c.requiredMissing
+	ch <- c.fileSecrets
+	ch <- c.secretsTotal
+	ch <- c.onboarding
+	ch <- c.templateFile
+	ch <- c.gitRepository
+	ch <- c.gitBranch
+	ch <- c.gitHeadTime
+	ch <- c.gitChanged
+	ch <- c.gitUntracked
+	ch <- c.gitAhead
+	ch <- c.gitBehind
 	ch <- c.memoryFilesCount
+	ch <- c.memoryBytes
+	ch <- c.memoryFileSize
+	ch <- c.memoryLatest
+	ch <- c.memoryDaysSince
+	ch <- c.memoryMissing
+	ch <- c.memoryNonDated
+	ch <- c.memoryMdSections
+	ch <- c.memoryMdEntries
+	ch <- c.memoryMdWords
+	ch <- c.memoryMdBytes
+	ch <- c.bootstrapChars
+	ch <- c.bootstrapTokens
+	ch <- c.bootstrapTrunc
+	ch <- c.bootstrapTotal
+	ch <- c.bootstrapMax
 	ch <- c.scrapeSuccess
 	ch <- c.scanDuration
 	ch <- c.scanErrors
@@ -224,28 +831,191 @@ func (c *OpenclawCollector) Collect(ch chan<- prometheus.Metric) {
 	scanErrorsTotal := c.scanErrorsTotal
 	c.mu.RUnlock()
 
+	for _, ws := range snapshot.workspaces {
+		c.collectWorkspace(ch, ws)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.agentsCount,
+		prometheus.GaugeValue,
+		float64(len(snapshot.agents)),
+	)
+
+	for _, agent := range snapshot.agents {
+		ch <- prometheus.MustNewConstMetric(
+			c.agentInfo,
+			prometheus.GaugeValue,
+			1,
+			agent.id, agent.workspace, agent.model, agent.source,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.systemSkillsDir,
+		prometheus.GaugeValue,
+		1,
+		snapshot.systemSkillsDir, snapshot.systemSkillsMethod,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bootstrapMax,
+		prometheus.GaugeValue,
+		float64(snapshot.bootstrapMaxChars),
+	)
+
+	installMethod := "none"
+	if snapshot.installFound {
+		installMethod = snapshot.install.method
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.installInfo,
+		prometheus.GaugeValue,
+		1,
+		snapshot.install.version, snapshot.configVersion, snapshot.install.dir, installMethod,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.scrapeSuccess,
+		prometheus.GaugeValue,
+		snapshot.scrapeSuccess,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.scanDuration,
+		prometheus.GaugeValue,
+		duration,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.scanErrors,
+		prometheus.CounterValue,
+		float64(scanErrorsTotal),
+	)
+}
+
+// collectWorkspace emits the metrics of a single workspace snapshot.
+func (c *OpenclawCollector) collectWorkspace(ch chan<- prometheus.Metric, snapshot workspaceSnapshot) {
+	agent, dir := snapshot.workspace.Agent, snapshot.workspace.Dir
+
 	for _, stat := range snapshot.fileStats {
 		ch <- prometheus.MustNewConstMetric(
 			c.fileSize,
 			prometheus.GaugeValue,
 			stat.size,
-			stat.name,
+			agent, dir, stat.name,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.fileMtime,
 			prometheus.GaugeValue,
 			stat.mtime,
-			stat.name,
+			agent, dir, stat.name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.fileTokens,
+			prometheus.GaugeValue,
+			stat.tokens,
+			agent, dir, stat.name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.fileChanges,
+			prometheus.CounterValue,
+			stat.contentChanges,
+			agent, dir, stat.name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.fileLastChange,
+			prometheus.GaugeValue,
+			stat.lastContentChange,
+			agent, dir, stat.name,
 		)
 	}
 
+	ch <- prometheus.MustNewConstMetric(
+		c.tokenizerInfo,
+		prometheus.GaugeValue,
+		1,
+		agent, dir, snapshot.model, snapshot.encoding,
+	)
+
 	for file, exists := range snapshot.workspaceExists {
 		ch <- prometheus.MustNewConstMetric(
 			c.workspaceFiles,
 			prometheus.GaugeValue,
 			exists,
-			file,
+			agent, dir, file,
+		)
+	}
+
+	c.collectGit(ch, agent, dir, snapshot.git)
+
+	if identity := snapshot.identity; identity.found {
+		ch <- prometheus.MustNewConstMetric(
+			c.agentIdentity,
+			prometheus.GaugeValue,
+			1,
+			agent, dir, identity.name, identity.creature, identity.vibe, identity.emoji,
+		)
+	}
+
+	if snapshot.secrets != nil {
+		secretsTotal := 0.0
+		for _, file := range snapshot.secrets {
+			for pattern, count := range file.counts {
+				ch <- prometheus.MustNewConstMetric(
+					c.fileSecrets,
+					prometheus.GaugeValue,
+					count,
+					agent, dir, file.name, pattern,
+				)
+				secretsTotal += count
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.secretsTotal,
+			prometheus.GaugeValue,
+			secretsTotal,
+			agent, dir,
+		)
+	}
+
+	if onboarding := snapshot.onboarding; onboarding.status != "" {
+		ch <- prometheus.MustNewConstMetric(
+			c.onboarding,
+			prometheus.GaugeValue,
+			1,
+			agent, dir, onboarding.status,
+		)
+
+		for _, file := range onboarding.templateFiles {
+			unchanged := 0.0
+			if file.unchanged {
+				unchanged = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(
+				c.templateFile,
+				prometheus.GaugeValue,
+				unchanged,
+				agent, dir, file.name,
+			)
+		}
+	}
+
+	for _, rule := range c.fileRules {
+		exists, ok := snapshot.workspaceExists[rule.Pattern]
+		if !rule.Required || !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.requiredMissing,
+			prometheus.GaugeValue,
+			1-exists,
+			agent, dir, rule.Pattern,
 		)
 	}
 
@@ -253,309 +1023,536 @@ func (c *OpenclawCollector) Collect(ch chan<- prometheus.Metric) {
 		c.contextLength,
 		prometheus.GaugeValue,
 		snapshot.contextLength,
+		agent, dir,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.contextTokens,
+		prometheus.GaugeValue,
+		snapshot.contextTokens,
+		agent, dir,
+	)
+
+	for _, stat := range snapshot.contextFiles {
+		ch <- prometheus.MustNewConstMetric(c.contextFileSize, prometheus.GaugeValue, stat.size, agent, dir, stat.name)
+		ch <- prometheus.MustNewConstMetric(c.contextFileMtime, prometheus.GaugeValue, stat.mtime, agent, dir, stat.name)
+		ch <- prometheus.MustNewConstMetric(c.contextFileToks, prometheus.GaugeValue, stat.tokens, agent, dir, stat.name)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.contextFiles,
+		prometheus.GaugeValue,
+		float64(len(snapshot.contextFiles)),
+		agent, dir,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.contextErrors,
+		prometheus.CounterValue,
+		snapshot.contextStatErrors,
+		agent, dir,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
 		c.skillsCount,
 		prometheus.GaugeValue,
-		snapshot.skillsCount,
+		float64(len(snapshot.skills)),
+		agent, dir,
+	)
+
+	bySource := map[string]int{
+		skillSourceLegacy:    0,
+		skillSourceWorkspace: 0,
+		skillSourceUser:      0,
+		skillSourceSystem:    0,
+	}
+	shadowed, duplicated, invalid, eligible := 0, 0, 0, 0
+	for _, skill := range snapshot.skills {
+		bySource[skill.source]++
+
+		ch <- prometheus.MustNewConstMetric(
+			c.skillInfo,
+			prometheus.GaugeValue,
+			1,
+			agent, dir, skill.name, skill.source, skill.dir,
+		)
+
+		valid, reason := 1.0, "none"
+		if skill.invalidReason != "" {
+			valid, reason = 0.0, skill.invalidReason
+			invalid++
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.skillValid,
+			prometheus.GaugeValue,
+			valid,
+			agent, dir, skill.name, skill.source, skill.dir, reason,
+		)
+
+		isEligible, missing := 1.0, "none"
+		if skill.missingRequirement != "" {
+			isEligible, missing = 0.0, skill.missingRequirement
+		} else if skill.effective {
+			eligible++
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.skillEligible,
+			prometheus.GaugeValue,
+			isEligible,
+			agent, dir, skill.name, skill.source, skill.dir, missing,
+		)
+
+		effective := 0.0
+		if skill.effective {
+			effective = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.skillEffective,
+			prometheus.GaugeValue,
+			effective,
+			agent, dir, skill.name, skill.source, skill.dir,
+		)
+
+		if skill.effective {
+			continue
+		}
+
+		if skill.shadowedBy == skill.source {
+			duplicated++
+		} else {
+			shadowed++
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.skillShadowed,
+			prometheus.GaugeValue,
+			1,
+			agent, dir, skill.name, skill.source, skill.dir, skill.shadowedBy,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.skillsShadowed,
+		prometheus.GaugeValue,
+		float64(shadowed),
+		agent, dir,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		c.agentsCount,
+		c.skillsDuplicated,
 		prometheus.GaugeValue,
-		snapshot.agentsCount,
+		float64(duplicated),
+		agent, dir,
 	)
 
+	ch <- prometheus.MustNewConstMetric(
+		c.skillsInvalid,
+		prometheus.GaugeValue,
+		float64(invalid),
+		agent, dir,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.skillsEligible,
+		prometheus.GaugeValue,
+		float64(eligible),
+		agent, dir,
+	)
+
+	for source, count := range bySource {
+		ch <- prometheus.MustNewConstMetric(
+			c.skillsBySource,
+			prometheus.GaugeValue,
+			float64(count),
+			agent, dir, source,
+		)
+	}
+
+	memory := snapshot.memory
+
 	ch <- prometheus.MustNewConstMetric(
 		c.memoryFilesCount,
 		prometheus.GaugeValue,
-		snapshot.memoryFiles,
+		float64(len(memory.files)),
+		agent, dir,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		c.scrapeSuccess,
+		c.memoryBytes,
 		prometheus.GaugeValue,
-		snapshot.scrapeSuccess,
+		memory.totalBytes,
+		agent, dir,
 	)
 
+	for _, file := range memory.files {
+		ch <- prometheus.MustNewConstMetric(
+			c.memoryFileSize,
+			prometheus.GaugeValue,
+			file.size,
+			agent, dir, file.name,
+		)
+	}
+
+	if !memory.latest.IsZero() {
+		ch <- prometheus.MustNewConstMetric(
+			c.memoryLatest,
+			prometheus.GaugeValue,
+			float64(memory.latest.Unix()),
+			agent, dir,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.memoryDaysSince,
+			prometheus.GaugeValue,
+			memory.daysSinceLatest,
+			agent, dir,
+		)
+	}
+
 	ch <- prometheus.MustNewConstMetric(
-		c.scanDuration,
+		c.memoryMissing,
 		prometheus.GaugeValue,
-		duration,
+		memory.missingDays,
+		agent, dir, strconv.Itoa(c.memoryWindow),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		c.scanErrors,
-		prometheus.CounterValue,
-		float64(scanErrorsTotal),
+		c.memoryNonDated,
+		prometheus.GaugeValue,
+		memory.nonConforming,
+		agent, dir,
+	)
+
+	if doc := snapshot.memoryDocument; doc.found {
+		ch <- prometheus.MustNewConstMetric(c.memoryMdSections, prometheus.GaugeValue, doc.sections, agent, dir)
+		ch <- prometheus.MustNewConstMetric(c.memoryMdEntries, prometheus.GaugeValue, doc.entries, agent, dir)
+		ch <- prometheus.MustNewConstMetric(c.memoryMdWords, prometheus.GaugeValue, doc.words, agent, dir)
+		ch <- prometheus.MustNewConstMetric(c.memoryMdBytes, prometheus.GaugeValue, doc.bytes, agent, dir)
+	}
+
+	var bootstrapTokens float64
+	for _, file := range snapshot.bootstrapFiles {
+		truncated := 0.0
+		if file.truncated {
+			truncated = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.bootstrapChars, prometheus.GaugeValue, file.chars, agent, dir, file.name)
+		ch <- prometheus.MustNewConstMetric(c.bootstrapTokens, prometheus.GaugeValue, file.tokens, agent, dir, file.name)
+		ch <- prometheus.MustNewConstMetric(c.bootstrapTrunc, prometheus.GaugeValue, truncated, agent, dir, file.name)
+		bootstrapTokens += file.tokens
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bootstrapTotal,
+		prometheus.GaugeValue,
+		bootstrapTokens,
+		agent, dir,
 	)
 }
 
-func (c *OpenclawCollector) collectFileMetrics(ctx context.Context, snapshot *scrapeSnapshot) error {
-	// Monitor core workspace files
-	// Use a map to track which files we've already seen (case-insensitive)
-	// to avoid counting both SOUL.md and soul.md
-	files := []string{
-		"AGENTS.md", "SOUL.md", "TOOLS.md", "IDENTITY.md",
-		"USER.md", "HEARTBEAT.md", "BOOTSTRAP.md", "BOOT.md", "MEMORY.md",
-		"soul.md", "skill.md", "agent.md", // legacy files (lowercase)
+// collectGit emits the git status of a workspace repository.
+func (c *OpenclawCollector) collectGit(ch chan<- prometheus.Metric, agent, dir string, status gitStatus) {
+	isRepo := 0.0
+	if status.isRepo {
+		isRepo = 1.0
 	}
+	ch <- prometheus.MustNewConstMetric(c.gitRepository, prometheus.GaugeValue, isRepo, agent, dir)
 
-	// Track which base names we've already reported (lowercase for case-insensitive check)
-	reported := make(map[string]bool)
+	if !status.isRepo {
+		return
+	}
 
-	for _, file := range files {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
+	ch <- prometheus.MustNewConstMetric(c.gitBranch, prometheus.GaugeValue, 1, agent, dir, status.branch, status.upstream)
+	ch <- prometheus.MustNewConstMetric(c.gitChanged, prometheus.GaugeValue, status.changed, agent, dir)
+	ch <- prometheus.MustNewConstMetric(c.gitUntracked, prometheus.GaugeValue, status.untracked, agent, dir)
 
-		// Get the lowercase version for deduplication check
-		fileLower := strings.ToLower(file)
+	if status.headTime > 0 {
+		ch <- prometheus.MustNewConstMetric(c.gitHeadTime, prometheus.GaugeValue, status.headTime, agent, dir)
+	}
 
-		// Check if we already reported this file (case-insensitive)
-		// Skip lowercase version if uppercase version exists
-		if reported[fileLower] {
-			continue
-		}
+	if status.upstream != "" {
+		ch <- prometheus.MustNewConstMetric(c.gitAhead, prometheus.GaugeValue, status.ahead, agent, dir)
+		ch <- prometheus.MustNewConstMetric(c.gitBehind, prometheus.GaugeValue, status.behind, agent, dir)
+	}
+}
 
-		path := filepath.Join(c.dir, file)
-		info, err := os.Stat(path)
-		if err != nil {
-			if os.IsNotExist(err) {
-				continue
-			}
-			return err
-		}
+func (c *OpenclawCollector) collectIdentityMetrics(ctx context.Context, ws Workspace, snapshot *workspaceSnapshot) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
-		// Mark this base name as reported
-		reported[fileLower] = true
+	identity, err := readAgentIdentity(ws.Dir)
+	snapshot.identity = identity
 
-		snapshot.fileStats = append(snapshot.fileStats, fileStat{
-			name:  file,
-			size:  float64(info.Size()),
-			mtime: float64(info.ModTime().Unix()),
-		})
+	return err
+}
+
+func (c *OpenclawCollector) collectOnboardingMetrics(ctx context.Context, ws Workspace, shared *sharedScan, snapshot *workspaceSnapshot) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	return nil
+	state, err := readOnboardingState(ws.Dir, shared.templates)
+	snapshot.onboarding = state
+
+	return err
 }
 
-func (c *OpenclawCollector) collectWorkspaceFileMetrics(ctx context.Context, snapshot *scrapeSnapshot) error {
-	// Check existence of key workspace files
-	workspaceFiles := []string{
-		"AGENTS.md", "SOUL.md", "TOOLS.md", "IDENTITY.md",
-		"USER.md", "HEARTBEAT.md", "BOOTSTRAP.md", "MEMORY.md",
+func (c *OpenclawCollector) collectGitMetrics(ctx context.Context, ws Workspace, snapshot *workspaceSnapshot) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
-	for _, file := range workspaceFiles {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
+	status, err := readGitStatus(ctx, ws.Dir, c.gitStatus)
+	snapshot.git = status
+
+	return err
+}
+
+func (c *OpenclawCollector) collectFileMetrics(ctx context.Context, ws Workspace, snapshot *workspaceSnapshot) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
-		path := filepath.Join(c.dir, file)
+	// Monitor the workspace files selected by the file rules
+	matches, err := matchFileRules(ws.Dir, c.fileRules)
+	if err != nil {
+		return err
+	}
+
+	// A file is reported once even if several rules select it
+	seen := make(map[string]bool)
+	for i, rule := range c.fileRules {
 		exists := 0.0
-		if _, err := os.Stat(path); err == nil {
+		if len(matches[i]) > 0 {
 			exists = 1.0
-		} else if err != nil && !os.IsNotExist(err) {
-			return err
 		}
+		snapshot.workspaceExists[rule.Pattern] = exists
+
+		for _, match := range matches[i] {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if seen[match.name] {
+				continue
+			}
+			seen[match.name] = true
+
+			path := filepath.Join(ws.Dir, match.name)
+			tokens, err := c.tokenizer.countFile(path, match.info, snapshot.encoding, 0)
+			if err != nil {
+				return err
+			}
+
+			stat := fileStat{
+				name:   match.name,
+				size:   float64(match.info.Size()),
+				mtime:  float64(match.info.ModTime().Unix()),
+				tokens: tokens,
+			}
+			if err := c.fileHistory.track(ws.Dir, path, &stat); err != nil {
+				return err
+			}
 
-		snapshot.workspaceExists[file] = exists
+			snapshot.fileStats = append(snapshot.fileStats, stat)
+		}
 	}
 
+	// Only a complete scan shows which files are gone
+	c.fileHistory.sweep(ws.Dir, seen, time.Now())
+
 	return nil
 }
 
-func (c *OpenclawCollector) collectMemoryMetrics(ctx context.Context, snapshot *scrapeSnapshot) error {
-	// Count daily memory files in memory/ directory
-	memoryDir := filepath.Join(c.dir, "memory")
-	count := 0
+func (c *OpenclawCollector) collectMemoryMetrics(ctx context.Context, ws Workspace, snapshot *workspaceSnapshot) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	entries, err := os.ReadDir(memoryDir)
+	now := time.Now()
+
+	// Daily memory files live in memory/, one per day
+	memory, err := scanMemoryDir(ctx, filepath.Join(ws.Dir, "memory"), now, c.location, c.memoryWindow)
+	snapshot.memory = memory
 	if err != nil {
-		if os.IsNotExist(err) {
-			snapshot.memoryFiles = 0
-			return nil
-		}
 		return err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".md" {
-			count++
-		}
+	// Long-term memory is curated in MEMORY.md
+	doc, err := readMemoryDocument(ws.Dir)
+	snapshot.memoryDocument = doc
+
+	return err
+}
+
+// collectSecretMetrics scans the monitored workspace files, context files
+// and daily memory files found by the earlier collectors for secrets.
+func (c *OpenclawCollector) collectSecretMetrics(ctx context.Context, ws Workspace, snapshot *workspaceSnapshot) error {
+	if c.secrets == nil {
+		return nil
+	}
+	snapshot.secrets = []fileSecrets{}
+
+	var names []string
+	for _, stat := range snapshot.fileStats {
+		names = append(names, stat.name)
+	}
+	for _, stat := range snapshot.contextFiles {
+		names = append(names, stat.name)
+	}
+	for _, file := range snapshot.memory.files {
+		names = append(names, "memory/"+file.name)
 	}
 
-	snapshot.memoryFiles = float64(count)
+	scanned := make(map[string]bool)
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if scanned[name] {
+			continue
+		}
+		scanned[name] = true
+
+		path := filepath.Join(ws.Dir, filepath.FromSlash(name))
+		info, err := os.Stat(path)
+		if err != nil {
+			// Removed since the earlier collectors listed it
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+
+		counts, err := c.secrets.scanFile(path, info)
+		if err != nil {
+			return err
+		}
+		snapshot.secrets = append(snapshot.secrets, fileSecrets{name: name, counts: counts})
+	}
 
 	return nil
 }
 
-func (c *OpenclawCollector) collectContextMetrics(ctx context.Context, snapshot *scrapeSnapshot) error {
-	contextFiles, err := filepath.Glob(filepath.Join(c.dir, "context*.md"))
+func (c *OpenclawCollector) collectBootstrapMetrics(ctx context.Context, ws Workspace, shared *sharedScan, snapshot *workspaceSnapshot) error {
+	files, err := scanBootstrapFiles(ctx, ws.Dir, shared.bootstrapMaxChars, c.tokenizer, snapshot.encoding)
+	snapshot.bootstrapFiles = files
+
+	return err
+}
+
+func (c *OpenclawCollector) collectContextMetrics(ctx context.Context, ws Workspace, previous *workspaceSnapshot, snapshot *workspaceSnapshot) error {
+	if previous != nil {
+		snapshot.contextStatErrors = previous.contextStatErrors
+	}
+
+	contextFiles, err := filepath.Glob(filepath.Join(ws.Dir, "context*.md"))
 	if err != nil {
 		return err
 	}
 
 	var totalLength int64
+	var totalTokens float64
 	for _, path := range contextFiles {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
 
+		// Files can disappear between the glob and the stat; count and move on
 		info, err := os.Stat(path)
 		if err != nil {
+			log.Printf("Error reading context file %s: %v", path, err)
+			snapshot.contextStatErrors++
+			continue
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		tokens, err := c.tokenizer.countFile(path, info, snapshot.encoding, 0)
+		if err != nil {
+			log.Printf("Error reading context file %s: %v", path, err)
+			snapshot.contextStatErrors++
 			continue
 		}
+
 		totalLength += info.Size()
+		totalTokens += tokens
+		snapshot.contextFiles = append(snapshot.contextFiles, fileStat{
+			name:   filepath.Base(path),
+			size:   float64(info.Size()),
+			mtime:  float64(info.ModTime().Unix()),
+			tokens: tokens,
+		})
 	}
 
 	snapshot.contextLength = float64(totalLength)
+	snapshot.contextTokens = totalTokens
 
 	return nil
 }
 
-func (c *OpenclawCollector) collectSkillsMetrics(ctx context.Context, snapshot *scrapeSnapshot) error {
-	totalCount := 0
+func (c *OpenclawCollector) collectSkillsMetrics(ctx context.Context, ws Workspace, shared *sharedScan, snapshot *workspaceSnapshot) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
 	// Check legacy skill.md file for H2 sections
-	skillPath := filepath.Join(c.dir, "skill.md")
-	if count, err := countMarkdownSections(skillPath); err == nil {
-		totalCount += count
+	legacy, err := scanLegacySkills(filepath.Join(ws.Dir, "skill.md"))
+	if err != nil {
+		return err
 	}
+	snapshot.skills = append(snapshot.skills, legacy...)
 
 	// Check workspace skills/ directory for SKILL.md files
-	skillsDir := filepath.Join(c.dir, "skills")
-	if entries, err := os.ReadDir(skillsDir); err == nil {
-		for _, entry := range entries {
-			if err := ctx.Err(); err != nil {
-				return err
-			}
-
-			if entry.IsDir() {
-				skillFile := filepath.Join(skillsDir, entry.Name(), "SKILL.md")
-				if _, err := os.Stat(skillFile); err == nil {
-					totalCount++
-				}
-			}
-		}
-	} else if err != nil && !os.IsNotExist(err) {
+	workspaceSkills, err := scanSkillsDir(ctx, filepath.Join(ws.Dir, "skills"), skillSourceWorkspace)
+	if err != nil {
 		return err
 	}
+	snapshot.skills = append(snapshot.skills, workspaceSkills...)
+
+	// User and system skills are shared by all workspaces
+	snapshot.skills = append(snapshot.skills, shared.skills...)
 
+	resolveSkillPrecedence(snapshot.skills)
+	evaluateSkillEligibility(snapshot.skills, shared.config)
+
+	return nil
+}
+
+// scanSharedSkills scans the skill sources shared by all workspaces: user
+// skills in the openclaw home and system skills in the openclaw package.
+func (c *OpenclawCollector) scanSharedSkills(ctx context.Context, systemSkillsDir string) ([]skillInfo, error) {
 	// Check user skills directory at ~/.openclaw/skills
-	homeDir := os.Getenv("HOME")
-	if homeDir != "" {
-		userSkillsDir := filepath.Join(homeDir, ".openclaw", "skills")
-		if entries, err := os.ReadDir(userSkillsDir); err == nil {
-			for _, entry := range entries {
-				if err := ctx.Err(); err != nil {
-					return err
-				}
-
-				if entry.IsDir() {
-					skillFile := filepath.Join(userSkillsDir, entry.Name(), "SKILL.md")
-					if _, err := os.Stat(skillFile); err == nil {
-						totalCount++
-					}
-				}
-			}
-		} else if err != nil && !os.IsNotExist(err) {
-			return err
-		}
+	skills, err := scanSkillsDir(ctx, filepath.Join(c.openclawHome, "skills"), skillSourceUser)
+	if err != nil {
+		return nil, err
 	}
 
 	// Check system skills directory (openclaw npm package)
-	// Can be overridden via OPENCLAW_SKILLS_DIR environment variable
-	systemSkillsDir := resolveSystemSkillsDir()
 	if systemSkillsDir != "" {
-		if entries, err := os.ReadDir(systemSkillsDir); err == nil {
-			for _, entry := range entries {
-				if err := ctx.Err(); err != nil {
-					return err
-				}
-
-				if entry.IsDir() {
-					skillFile := filepath.Join(systemSkillsDir, entry.Name(), "SKILL.md")
-					if _, err := os.Stat(skillFile); err == nil {
-						totalCount++
-					}
-				}
-			}
-		} else if !os.IsNotExist(err) {
-			return err
+		systemSkills, err := scanSkillsDir(ctx, systemSkillsDir, skillSourceSystem)
+		if err != nil {
+			return skills, err
 		}
+		skills = append(skills, systemSkills...)
 	}
 
-	snapshot.skillsCount = float64(totalCount)
-
-	return nil
+	return skills, nil
 }
 
 func (c *OpenclawCollector) collectAgentsMetrics(ctx context.Context, snapshot *scrapeSnapshot) error {
-	totalCount := 0
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	// Check legacy agent.md file for agent definitions (H2 sections)
+	// Agents come from openclaw.json and the agents/ state directories
 	// Note: AGENTS.md is a workspace configuration document, not an agent list
-	agentPath := filepath.Join(c.dir, "agent.md")
-	if count, err := countMarkdownSections(agentPath); err == nil {
-		totalCount += count
-	}
-
-	snapshot.agentsCount = float64(totalCount)
-
-	return nil
-}
-
-func resolveSystemSkillsDir() string {
-	if systemSkillsDir := os.Getenv("OPENCLAW_SKILLS_DIR"); systemSkillsDir != "" {
-		return systemSkillsDir
-	}
-
-	if runtime.GOOS == "darwin" {
-		if _, err := os.Stat(defaultSystemSkillsDir); err == nil {
-			return defaultSystemSkillsDir
-		}
-	}
-
-	return ""
-}
-
-// countMarkdownSections counts the number of H2 sections (##) in a markdown file.
-func countMarkdownSections(path string) (int, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-
-	count := 0
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if strings.HasPrefix(line, "##") {
-			rest := strings.TrimLeft(line[2:], " \t")
-			if rest != "" {
-				count++
-			}
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return 0, err
-	}
+	agents, err := discoverAgents(c.openclawHome)
+	snapshot.agents = agents
 
-	return count, nil
+	return err
 }
 
 // ResponseLatencyCollector tracks response latency metrics.
@@ -0,0 +1,183 @@
+package collector
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ConfigCollector collects gateway configuration metrics from openclaw.json.
+type ConfigCollector struct {
+	openclawHome string
+
+	configInfo        *prometheus.Desc
+	configHash        *prometheus.Desc
+	configModified    *prometheus.Desc
+	agentsTotal       *prometheus.Desc
+	agentInfo         *prometheus.Desc
+	modelFallback     *prometheus.Desc
+	channelEnabled    *prometheus.Desc
+	heartbeatInterval *prometheus.Desc
+	scrapeSuccess     *prometheus.Desc
+}
+
+// NewConfigCollector creates a new ConfigCollector.
+func NewConfigCollector(openclawHome string) *ConfigCollector {
+	if openclawHome == "" {
+		openclawHome = os.Getenv("HOME") + defaultOpenclawHome
+	}
+
+	return &ConfigCollector{
+		openclawHome: openclawHome,
+		configInfo: prometheus.NewDesc(
+			"openclaw_config_info",
+			"Gateway configuration information",
+			[]string{"default_model", "sandbox_mode"}, nil,
+		),
+		configHash: prometheus.NewDesc(
+			"openclaw_config_hash_info",
+			"SHA-256 hash of the configuration file contents",
+			[]string{"hash"}, nil,
+		),
+		configModified: prometheus.NewDesc(
+			"openclaw_config_last_modified_timestamp_seconds",
+			"Last modification time of the configuration file in seconds since epoch",
+			nil, nil,
+		),
+		agentsTotal: prometheus.NewDesc(
+			"openclaw_config_agents_total",
+			"Number of agents configured in agents.list",
+			nil, nil,
+		),
+		agentInfo: prometheus.NewDesc(
+			"openclaw_config_agent_info",
+			"Configured agent information",
+			[]string{"agent", "name", "model", "default"}, nil,
+		),
+		modelFallback: prometheus.NewDesc(
+			"openclaw_config_model_fallback_info",
+			"Configured default model fallbacks in order of preference",
+			[]string{"model", "position"}, nil,
+		),
+		channelEnabled: prometheus.NewDesc(
+			"openclaw_config_channel_enabled",
+			"Whether a configured channel is enabled",
+			[]string{"channel"}, nil,
+		),
+		heartbeatInterval: prometheus.NewDesc(
+			"openclaw_config_heartbeat_interval_seconds",
+			"Configured default heartbeat interval in seconds (0 = disabled)",
+			nil, nil,
+		),
+		scrapeSuccess: prometheus.NewDesc(
+			"openclaw_config_scrape_success",
+			"Whether the configuration file was read and parsed successfully",
+			nil, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *ConfigCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.configInfo
+	ch <- c.configHash
+	ch <- c.configModified
+	ch <- c.agentsTotal
+	ch <- c.agentInfo
+	ch <- c.modelFallback
+	ch <- c.channelEnabled
+	ch <- c.heartbeatInterval
+	ch <- c.scrapeSuccess
+}
+
+// Collect implements prometheus.Collector.
+func (c *ConfigCollector) Collect(ch chan<- prometheus.Metric) {
+	loaded, err := loadOpenclawConfig(c.openclawHome)
+	if err != nil {
+		log.Printf("Error loading openclaw config: %v", err)
+		ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 0)
+		return
+	}
+
+	cfg := &loaded.config
+	defaults := cfg.Agents.Defaults
+
+	ch <- prometheus.MustNewConstMetric(
+		c.configInfo,
+		prometheus.GaugeValue,
+		1,
+		defaults.Model.Primary, defaults.Sandbox.Mode,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.configHash,
+		prometheus.GaugeValue,
+		1,
+		loaded.hash,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.configModified,
+		prometheus.GaugeValue,
+		float64(loaded.modTime.Unix()),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.agentsTotal,
+		prometheus.GaugeValue,
+		float64(len(cfg.Agents.List)),
+	)
+
+	agents, err := discoverAgentsWithConfig(c.openclawHome, cfg)
+	if err != nil {
+		log.Printf("Error discovering agents: %v", err)
+	}
+	for _, agent := range agents {
+		if !agent.listed {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.agentInfo,
+			prometheus.GaugeValue,
+			1,
+			agent.id, agent.name, agent.model, strconv.FormatBool(agent.isDefault),
+		)
+	}
+
+	for i, model := range defaults.Model.Fallbacks {
+		ch <- prometheus.MustNewConstMetric(
+			c.modelFallback,
+			prometheus.GaugeValue,
+			1,
+			model, strconv.Itoa(i+1),
+		)
+	}
+
+	for channel, enabled := range cfg.enabledChannels() {
+		value := 0.0
+		if enabled {
+			value = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.channelEnabled,
+			prometheus.GaugeValue,
+			value,
+			channel,
+		)
+	}
+
+	if interval, err := cfg.heartbeatInterval(); err == nil {
+		ch <- prometheus.MustNewConstMetric(
+			c.heartbeatInterval,
+			prometheus.GaugeValue,
+			interval.Seconds(),
+		)
+	} else {
+		log.Printf("Error parsing heartbeat interval: %v", err)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 1)
+}
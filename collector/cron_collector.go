@@ -0,0 +1,397 @@
+package collector
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CronCollector collects scheduled job metrics from the openclaw cron store.
+type CronCollector struct {
+	openclawHome string
+
+	jobsTotal           *prometheus.Desc
+	jobInfo             *prometheus.Desc
+	jobEnabled          *prometheus.Desc
+	jobLastRun          *prometheus.Desc
+	jobLastStatus       *prometheus.Desc
+	jobConsecutiveFails *prometheus.Desc
+	jobNextRun          *prometheus.Desc
+	jobLastDuration     *prometheus.Desc
+	jobRuns             *prometheus.Desc
+	scrapeSuccess       *prometheus.Desc
+}
+
+// NewCronCollector creates a new CronCollector.
+func NewCronCollector(openclawHome string) *CronCollector {
+	if openclawHome == "" {
+		openclawHome = os.Getenv("HOME") + defaultOpenclawHome
+	}
+
+	return &CronCollector{
+		openclawHome: openclawHome,
+		jobsTotal: prometheus.NewDesc(
+			"openclaw_cron_jobs_total",
+			"Total number of jobs in the cron store",
+			nil, nil,
+		),
+		jobInfo: prometheus.NewDesc(
+			"openclaw_cron_job_info",
+			"Cron job information",
+			[]string{"job_id", "agent", "name", "schedule"}, nil,
+		),
+		jobEnabled: prometheus.NewDesc(
+			"openclaw_cron_job_enabled",
+			"Whether the cron job is enabled",
+			[]string{"job_id", "agent"}, nil,
+		),
+		jobLastRun: prometheus.NewDesc(
+			"openclaw_cron_job_last_run_timestamp_seconds",
+			"Start time of the last cron job run in seconds since epoch",
+			[]string{"job_id", "agent"}, nil,
+		),
+		jobLastStatus: prometheus.NewDesc(
+			"openclaw_cron_job_last_status",
+			"Status of the last cron job run (value=1 for the current status)",
+			[]string{"job_id", "agent", "status"}, nil,
+		),
+		jobConsecutiveFails: prometheus.NewDesc(
+			"openclaw_cron_job_consecutive_failures",
+			"Number of consecutive failed runs of the cron job",
+			[]string{"job_id", "agent"}, nil,
+		),
+		jobNextRun: prometheus.NewDesc(
+			"openclaw_cron_job_next_run_timestamp_seconds",
+			"Next scheduled run time of the cron job in seconds since epoch",
+			[]string{"job_id", "agent"}, nil,
+		),
+		jobLastDuration: prometheus.NewDesc(
+			"openclaw_cron_job_last_duration_seconds",
+			"Duration of the last cron job run in seconds",
+			[]string{"job_id", "agent"}, nil,
+		),
+		jobRuns: prometheus.NewDesc(
+			"openclaw_cron_job_runs_total",
+			"Finished cron job runs still in the run log, by status",
+			[]string{"job_id", "agent", "status"}, nil,
+		),
+		scrapeSuccess: prometheus.NewDesc(
+			"openclaw_cron_scrape_success",
+			"Whether the cron store scrape was successful",
+			nil, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *CronCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.jobsTotal
+	ch <- c.jobInfo
+	ch <- c.jobEnabled
+	ch <- c.jobLastRun
+	ch <- c.jobLastStatus
+	ch <- c.jobConsecutiveFails
+	ch <- c.jobNextRun
+	ch <- c.jobLastDuration
+	ch <- c.jobRuns
+	ch <- c.scrapeSuccess
+}
+
+// cronStoreJSON represents the cron/jobs.json structure
+type cronStoreJSON struct {
+	Version int       `json:"version"`
+	Jobs    []cronJob `json:"jobs"`
+}
+
+type cronJob struct {
+	ID       string `json:"id"`
+	AgentID  string `json:"agentId"`
+	Name     string `json:"name"`
+	Enabled  bool   `json:"enabled"`
+	Schedule struct {
+		Kind    string `json:"kind"`
+		Expr    string `json:"expr"`
+		EveryMs int64  `json:"everyMs"`
+		AtMs    int64  `json:"atMs"`
+		At      string `json:"at"`
+	} `json:"schedule"`
+	State struct {
+		NextRunAtMs       int64  `json:"nextRunAtMs"`
+		LastRunAtMs       int64  `json:"lastRunAtMs"`
+		LastStatus        string `json:"lastStatus"`
+		LastDurationMs    *int64 `json:"lastDurationMs"`
+		ConsecutiveErrors *int   `json:"consecutiveErrors"`
+	} `json:"state"`
+}
+
+// cronRunEntry represents a line in a cron/runs/<jobId>.jsonl run log
+type cronRunEntry struct {
+	Ts         int64  `json:"ts"`
+	JobID      string `json:"jobId"`
+	Action     string `json:"action"`
+	Status     string `json:"status"`
+	RunAtMs    int64  `json:"runAtMs"`
+	DurationMs *int64 `json:"durationMs"`
+}
+
+// cronRunHistory summarises a job's run log.
+type cronRunHistory struct {
+	runsByStatus      map[string]int
+	consecutiveErrors int
+	last              *cronRunEntry
+}
+
+// Collect implements prometheus.Collector.
+func (c *CronCollector) Collect(ch chan<- prometheus.Metric) {
+	cronDir := filepath.Join(c.openclawHome, "cron")
+
+	data, err := os.ReadFile(filepath.Join(cronDir, "jobs.json"))
+	if err != nil {
+		// No cron store simply means no jobs have been scheduled yet
+		if os.IsNotExist(err) {
+			ch <- prometheus.MustNewConstMetric(c.jobsTotal, prometheus.GaugeValue, 0)
+			ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 1)
+			return
+		}
+		log.Printf("Error reading cron store: %v", err)
+		ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 0)
+		return
+	}
+
+	var store cronStoreJSON
+	if err := json.Unmarshal(data, &store); err != nil {
+		log.Printf("Error parsing cron store: %v", err)
+		ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 0)
+		return
+	}
+
+	jobs := uniqueCronJobs(store.Jobs)
+
+	// Jobs without an agentId run on the default agent; config errors are
+	// reported by the config collector
+	agents, err := discoverAgents(c.openclawHome)
+	if err != nil {
+		log.Printf("Error discovering agents for cron jobs: %v", err)
+	}
+	fallbackAgent := defaultAgent(agents)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.jobsTotal,
+		prometheus.GaugeValue,
+		float64(len(jobs)),
+	)
+
+	for _, job := range jobs {
+		history, err := readCronRunLog(filepath.Join(cronDir, "runs", filepath.Base(job.ID)+".jsonl"))
+		if err != nil && !os.IsNotExist(err) {
+			log.Printf("Error reading cron run log for job %s: %v", job.ID, err)
+		}
+
+		c.collectJobMetrics(ch, job, fallbackAgent, history)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 1)
+}
+
+func (c *CronCollector) collectJobMetrics(ch chan<- prometheus.Metric, job cronJob, fallbackAgent string, history cronRunHistory) {
+	agent := job.AgentID
+	if agent == "" {
+		agent = fallbackAgent
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.jobInfo,
+		prometheus.GaugeValue,
+		1,
+		job.ID, agent, job.Name, describeCronSchedule(job),
+	)
+
+	enabled := 0.0
+	if job.Enabled {
+		enabled = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.jobEnabled,
+		prometheus.GaugeValue,
+		enabled,
+		job.ID, agent,
+	)
+
+	// Prefer the job state; fall back to the run log for older stores
+	lastRunMs := job.State.LastRunAtMs
+	lastStatus := job.State.LastStatus
+	lastDurationMs := job.State.LastDurationMs
+	if history.last != nil {
+		if lastRunMs == 0 {
+			lastRunMs = history.last.RunAtMs
+			if lastRunMs == 0 {
+				lastRunMs = history.last.Ts
+			}
+		}
+		if lastStatus == "" {
+			lastStatus = history.last.Status
+		}
+		if lastDurationMs == nil {
+			lastDurationMs = history.last.DurationMs
+		}
+	}
+
+	if lastRunMs > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			c.jobLastRun,
+			prometheus.GaugeValue,
+			float64(lastRunMs/1000), // Convert ms to seconds
+			job.ID, agent,
+		)
+	}
+
+	if lastStatus != "" {
+		ch <- prometheus.MustNewConstMetric(
+			c.jobLastStatus,
+			prometheus.GaugeValue,
+			1,
+			job.ID, agent, lastStatus,
+		)
+	}
+
+	if lastDurationMs != nil {
+		ch <- prometheus.MustNewConstMetric(
+			c.jobLastDuration,
+			prometheus.GaugeValue,
+			(time.Duration(*lastDurationMs) * time.Millisecond).Seconds(),
+			job.ID, agent,
+		)
+	}
+
+	consecutiveErrors := history.consecutiveErrors
+	if job.State.ConsecutiveErrors != nil {
+		consecutiveErrors = *job.State.ConsecutiveErrors
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.jobConsecutiveFails,
+		prometheus.GaugeValue,
+		float64(consecutiveErrors),
+		job.ID, agent,
+	)
+
+	if job.State.NextRunAtMs > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			c.jobNextRun,
+			prometheus.GaugeValue,
+			float64(job.State.NextRunAtMs/1000), // Convert ms to seconds
+			job.ID, agent,
+		)
+	}
+
+	for status, count := range history.runsByStatus {
+		ch <- prometheus.MustNewConstMetric(
+			c.jobRuns,
+			prometheus.GaugeValue,
+			float64(count),
+			job.ID, agent, status,
+		)
+	}
+}
+
+// uniqueCronJobs returns the jobs with a valid ID, keeping the first job of
+// each ID. IDs name the job's run log, so IDs containing path separators or
+// dot segments are rejected rather than read outside cron/runs.
+func uniqueCronJobs(jobs []cronJob) []cronJob {
+	seen := make(map[string]bool)
+	unique := make([]cronJob, 0, len(jobs))
+
+	for _, job := range jobs {
+		if job.ID == "" {
+			continue
+		}
+		if !validCronJobID(job.ID) {
+			log.Printf("Skipping cron job with invalid id %q", job.ID)
+			continue
+		}
+		if seen[job.ID] {
+			log.Printf("Skipping duplicate cron job id %q", job.ID)
+			continue
+		}
+		seen[job.ID] = true
+		unique = append(unique, job)
+	}
+
+	return unique
+}
+
+// validCronJobID reports whether id can safely name a run log file.
+func validCronJobID(id string) bool {
+	return id != "." && id != ".." && !strings.ContainsAny(id, `/\`) && filepath.Base(id) == id
+}
+
+// readCronRunLog summarises the finished runs recorded in a job's run log.
+func readCronRunLog(path string) (cronRunHistory, error) {
+	history := cronRunHistory{runsByStatus: make(map[string]int)}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return history, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Increase buffer size for large lines (run summaries)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry cronRunEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		if entry.Action != "" && entry.Action != "finished" {
+			continue
+		}
+
+		status := entry.Status
+		if status == "" {
+			status = "unknown"
+		}
+		history.runsByStatus[status]++
+
+		if status == "error" {
+			history.consecutiveErrors++
+		} else if status != "skipped" {
+			history.consecutiveErrors = 0
+		}
+
+		history.last = &entry
+	}
+
+	return history, scanner.Err()
+}
+
+// describeCronSchedule renders a job schedule as a short label value.
+func describeCronSchedule(job cronJob) string {
+	switch job.Schedule.Kind {
+	case "cron":
+		return job.Schedule.Expr
+	case "every":
+		return "every " + (time.Duration(job.Schedule.EveryMs) * time.Millisecond).String()
+	case "at":
+		if job.Schedule.At != "" {
+			return "at " + job.Schedule.At
+		}
+		if job.Schedule.AtMs > 0 {
+			return "at " + time.UnixMilli(job.Schedule.AtMs).UTC().Format(time.RFC3339)
+		}
+	}
+
+	return job.Schedule.Kind
+}
@@ -0,0 +1,51 @@
+package collector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidCronJobID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"daily-summary", true},
+		{"3f2b9c1e-7d4a-4b8e-9a61-0c5d2e8f1a34", true},
+		{"job.v2", true},
+		{"../../x", false},
+		{"runs/x", false},
+		{`..\x`, false},
+		{"..", false},
+		{".", false},
+	}
+
+	for _, tt := range tests {
+		if got := validCronJobID(tt.id); got != tt.want {
+			t.Errorf("validCronJobID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestUniqueCronJobs(t *testing.T) {
+	jobs := []cronJob{
+		{ID: "a", Name: "first"},
+		{ID: ""},
+		{ID: "../../etc/passwd"},
+		{ID: "b"},
+		{ID: "a", Name: "duplicate"},
+	}
+
+	var ids, names []string
+	for _, job := range uniqueCronJobs(jobs) {
+		ids = append(ids, job.ID)
+		names = append(names, job.Name)
+	}
+
+	if want := []string{"a", "b"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if names[0] != "first" {
+		t.Errorf("kept job %q, want the first job of a duplicated id", names[0])
+	}
+}
@@ -0,0 +1,46 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+
+	"go.yaml.in/yaml/v2"
+)
+
+// ExporterConfig is the exporter's own YAML configuration file.
+type ExporterConfig struct {
+	// Workspaces to monitor in addition to those given on the command line
+	Workspaces []Workspace `yaml:"workspaces"`
+
+	// Tokenizer selects the encoding used to count tokens per model family
+	Tokenizer TokenizerConfig `yaml:"tokenizer"`
+
+	// Files replaces the default set of monitored workspace files
+	Files []FileRule `yaml:"files"`
+
+	// Secrets enables scanning workspace files for leaked secrets
+	Secrets SecretScanConfig `yaml:"secrets"`
+}
+
+// LoadExporterConfig reads the exporter configuration file at path.
+func LoadExporterConfig(path string) (*ExporterConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg ExporterConfig
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	for i := range cfg.Workspaces {
+		cfg.Workspaces[i].Dir = expandHome(cfg.Workspaces[i].Dir)
+	}
+
+	if err := validateFileRules(cfg.Files); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,67 @@
+package collector
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// fileCache holds values computed from files, each valid while its file's
+// size and modification time are unchanged. It is safe for concurrent use.
+type fileCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]fileCacheEntry[V]
+}
+
+type fileCacheEntry[V any] struct {
+	size    int64
+	modTime time.Time
+	value   V
+	// used is set when the entry is read or written since the last prune
+	used bool
+}
+
+func newFileCache[K comparable, V any]() *fileCache[K, V] {
+	return &fileCache[K, V]{entries: make(map[K]fileCacheEntry[V])}
+}
+
+// get returns the value cached for key if the file described by info is
+// unchanged.
+func (c *fileCache[K, V]) get(key K, info os.FileInfo) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.size != info.Size() || !entry.modTime.Equal(info.ModTime()) {
+		var zero V
+		return zero, false
+	}
+	entry.used = true
+	c.entries[key] = entry
+
+	return entry.value, true
+}
+
+// put caches value for key, computed from the file described by info.
+func (c *fileCache[K, V]) put(key K, info os.FileInfo, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = fileCacheEntry[V]{size: info.Size(), modTime: info.ModTime(), value: value, used: true}
+}
+
+// prune drops the entries not used since the previous prune, so that
+// deleted or renamed files do not accumulate. It is called after each scan.
+func (c *fileCache[K, V]) prune() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if !entry.used {
+			delete(c.entries, key)
+			continue
+		}
+		entry.used = false
+		c.entries[key] = entry
+	}
+}
@@ -0,0 +1,132 @@
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// How long the change history of a file that disappeared from its workspace
+// is kept, so that a file recreated later continues its history
+const fileHistoryRetention = time.Hour
+
+// fileHistoryKey identifies a monitored file across scans.
+type fileHistoryKey struct {
+	dir  string
+	name string
+}
+
+// fileHistory is the content change history of a monitored file.
+type fileHistory struct {
+	size              float64
+	mtime             float64
+	hash              string
+	contentChanges    float64
+	lastContentChange float64
+
+	// missingSince is set while the file is absent from its workspace
+	missingSince time.Time
+}
+
+// fileHistories holds the change history of every monitored file. Unlike
+// the scan snapshot it survives failed and partial scans, and the history
+// of a file is only dropped once the file has been gone for
+// fileHistoryRetention. It is safe for concurrent use.
+type fileHistories struct {
+	mu    sync.Mutex
+	files map[fileHistoryKey]*fileHistory
+}
+
+func newFileHistories() *fileHistories {
+	return &fileHistories{files: make(map[fileHistoryKey]*fileHistory)}
+}
+
+// track sets the content hash and change history of stat, the file name in
+// the workspace dir read from path. A file is only re-hashed when its size
+// or modification time changed or it reappeared, and only a different hash
+// counts as a change, so touching a file without editing it is ignored.
+func (h *fileHistories) track(dir, path string, stat *fileStat) error {
+	key := fileHistoryKey{dir: dir, name: stat.name}
+
+	h.mu.Lock()
+	var previous *fileHistory
+	if entry, ok := h.files[key]; ok {
+		copied := *entry
+		previous = &copied
+	}
+	h.mu.Unlock()
+
+	var current fileHistory
+	if previous != nil && previous.missingSince.IsZero() && previous.size == stat.size && previous.mtime == stat.mtime {
+		current = *previous
+	} else {
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+
+		current = fileHistory{size: stat.size, mtime: stat.mtime, hash: hash}
+		switch {
+		case previous == nil:
+			// Content is at least as old as the last modification
+			current.lastContentChange = stat.mtime
+		case previous.hash != hash:
+			current.contentChanges = previous.contentChanges + 1
+			current.lastContentChange = stat.mtime
+		default:
+			current.contentChanges = previous.contentChanges
+			current.lastContentChange = previous.lastContentChange
+		}
+	}
+
+	h.mu.Lock()
+	h.files[key] = &current
+	h.mu.Unlock()
+
+	stat.hash = current.hash
+	stat.contentChanges = current.contentChanges
+	stat.lastContentChange = current.lastContentChange
+
+	return nil
+}
+
+// sweep records which files of the workspace dir were present in a
+// complete scan. Files not present are marked missing, and dropped once they
+// have been missing for longer than fileHistoryRetention.
+func (h *fileHistories) sweep(dir string, present map[string]bool, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for key, entry := range h.files {
+		if key.dir != dir || present[key.name] {
+			continue
+		}
+
+		if entry.missingSince.IsZero() {
+			entry.missingSince = now
+			continue
+		}
+		if now.Sub(entry.missingSince) > fileHistoryRetention {
+			delete(h.files, key)
+		}
+	}
+}
+
+// hashFile returns the hex SHA-256 of a file's contents.
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,178 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileHistoriesTrack(t *testing.T) {
+	type step struct {
+		// content is written before the scan unless remove is set; an empty
+		// content leaves the file untouched
+		content string
+		mtime   time.Time
+		remove  bool
+		// partial skips the sweep, as a scan that fails before completing
+		partial bool
+		// elapsed advances the sweep clock before the scan
+		elapsed time.Duration
+
+		wantChanges    float64
+		wantLastChange time.Time
+	}
+
+	base := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return base.Add(time.Duration(minutes) * time.Minute) }
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "new file starts at its modification time",
+			steps: []step{
+				{content: "a", mtime: at(0), wantLastChange: at(0)},
+			},
+		},
+		{
+			name: "edits are counted",
+			steps: []step{
+				{content: "a", mtime: at(0), wantLastChange: at(0)},
+				{content: "b", mtime: at(1), wantChanges: 1, wantLastChange: at(1)},
+				{content: "c", mtime: at(2), wantChanges: 2, wantLastChange: at(2)},
+			},
+		},
+		{
+			name: "touch without edit is ignored",
+			steps: []step{
+				{content: "a", mtime: at(0), wantLastChange: at(0)},
+				{content: "a", mtime: at(5), wantLastChange: at(0)},
+			},
+		},
+		{
+			name: "history survives a missing scan",
+			steps: []step{
+				{content: "a", mtime: at(0), wantLastChange: at(0)},
+				{content: "b", mtime: at(1), wantChanges: 1, wantLastChange: at(1)},
+				{remove: true},
+				{content: "c", mtime: at(3), wantChanges: 2, wantLastChange: at(3)},
+			},
+		},
+		{
+			name: "recreated with the same content is not a change",
+			steps: []step{
+				{content: "a", mtime: at(0), wantLastChange: at(0)},
+				{remove: true},
+				{content: "a", mtime: at(2), wantLastChange: at(0)},
+			},
+		},
+		{
+			name: "recreated with the same size and mtime but new content",
+			steps: []step{
+				{content: "a", mtime: at(0), wantLastChange: at(0)},
+				{remove: true},
+				{content: "b", mtime: at(0), wantChanges: 1, wantLastChange: at(0)},
+			},
+		},
+		{
+			name: "history survives partial scans",
+			steps: []step{
+				{content: "a", mtime: at(0), wantLastChange: at(0)},
+				{remove: true, partial: true, elapsed: 2 * fileHistoryRetention},
+				{remove: true, partial: true, elapsed: 2 * fileHistoryRetention},
+				{content: "b", mtime: at(1), wantChanges: 1, wantLastChange: at(1)},
+			},
+		},
+		{
+			name: "history is dropped once the file is gone",
+			steps: []step{
+				{content: "a", mtime: at(0), wantLastChange: at(0)},
+				{content: "b", mtime: at(1), wantChanges: 1, wantLastChange: at(1)},
+				{remove: true},
+				{remove: true, elapsed: fileHistoryRetention + time.Minute},
+				{content: "c", mtime: at(90), wantLastChange: at(90)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "SOUL.md")
+			histories := newFileHistories()
+			now := base
+
+			for i, s := range tt.steps {
+				now = now.Add(s.elapsed)
+
+				if s.remove {
+					if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+						t.Fatal(err)
+					}
+					if !s.partial {
+						histories.sweep(dir, map[string]bool{}, now)
+					}
+					continue
+				}
+
+				if err := os.WriteFile(path, []byte(s.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Chtimes(path, s.mtime, s.mtime); err != nil {
+					t.Fatal(err)
+				}
+				info, err := os.Stat(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				stat := fileStat{name: "SOUL.md", size: float64(info.Size()), mtime: float64(info.ModTime().Unix())}
+				if err := histories.track(dir, path, &stat); err != nil {
+					t.Fatal(err)
+				}
+				if !s.partial {
+					histories.sweep(dir, map[string]bool{"SOUL.md": true}, now)
+				}
+
+				if stat.contentChanges != s.wantChanges {
+					t.Errorf("step %d: contentChanges = %v, want %v", i, stat.contentChanges, s.wantChanges)
+				}
+				if want := float64(s.wantLastChange.Unix()); stat.lastContentChange != want {
+					t.Errorf("step %d: lastContentChange = %v, want %v", i, stat.lastContentChange, want)
+				}
+				if stat.hash == "" {
+					t.Errorf("step %d: hash not set", i)
+				}
+			}
+		})
+	}
+}
+
+func TestFileHistoriesSweepOtherWorkspace(t *testing.T) {
+	histories := newFileHistories()
+	histories.files[fileHistoryKey{dir: "/ws/main", name: "SOUL.md"}] = &fileHistory{contentChanges: 3}
+
+	now := time.Now()
+	histories.sweep("/ws/work", map[string]bool{}, now)
+	histories.sweep("/ws/work", map[string]bool{}, now.Add(2*fileHistoryRetention))
+
+	entry, ok := histories.files[fileHistoryKey{dir: "/ws/main", name: "SOUL.md"}]
+	if !ok || !entry.missingSince.IsZero() {
+		t.Errorf("sweeping one workspace changed the history of another: %+v", entry)
+	}
+}
+
+func TestFileHistoriesTrackMissingFile(t *testing.T) {
+	histories := newFileHistories()
+	dir := t.TempDir()
+
+	stat := fileStat{name: "SOUL.md", size: 1, mtime: 1}
+	if err := histories.track(dir, filepath.Join(dir, "SOUL.md"), &stat); err == nil {
+		t.Error("track of a missing file returned no error")
+	}
+	if len(histories.files) != 0 {
+		t.Errorf("failed track recorded %d histories, want 0", len(histories.files))
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package collector
+
+import "os"
+
+// fileOwner is not supported on this platform.
+func fileOwner(info os.FileInfo) (uint32, bool) {
+	return 0, false
+}
@@ -0,0 +1,118 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileRule selects workspace files to monitor by exact name or glob
+// pattern, matched case-insensitively against files in the workspace root.
+type FileRule struct {
+	Pattern  string `yaml:"pattern"`
+	Required bool   `yaml:"required"`
+}
+
+// defaultFileRules are the workspace files openclaw creates or reads,
+// including legacy lowercase names.
+var defaultFileRules = []FileRule{
+	{Pattern: "AGENTS.md", Required: true},
+	{Pattern: "SOUL.md", Required: true},
+	{Pattern: "TOOLS.md", Required: true},
+	{Pattern: "IDENTITY.md", Required: true},
+	{Pattern: "USER.md", Required: true},
+	{Pattern: "HEARTBEAT.md"},
+	{Pattern: "BOOTSTRAP.md"},
+	{Pattern: "BOOT.md"},
+	{Pattern: "MEMORY.md"},
+	{Pattern: "skill.md"},
+	{Pattern: "agent.md"},
+}
+
+// validate reports patterns that are empty, malformed or point outside the
+// workspace root.
+func (r FileRule) validate() error {
+	if r.Pattern == "" {
+		return fmt.Errorf("file rule without pattern")
+	}
+	if strings.ContainsAny(r.Pattern, `/\`) {
+		return fmt.Errorf("file rule %q: patterns match files in the workspace root only", r.Pattern)
+	}
+	if _, err := filepath.Match(r.Pattern, ""); err != nil {
+		return fmt.Errorf("file rule %q: %w", r.Pattern, err)
+	}
+	return nil
+}
+
+// validateFileRules validates each rule and rejects patterns that repeat
+// an earlier one, ignoring case, since both would export the same series.
+func validateFileRules(rules []FileRule) error {
+	seen := make(map[string]string)
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+
+		key := strings.ToLower(rule.Pattern)
+		if first, ok := seen[key]; ok {
+			return fmt.Errorf("file rule %q: duplicates %q", rule.Pattern, first)
+		}
+		seen[key] = rule.Pattern
+	}
+	return nil
+}
+
+// matches reports whether a file name matches the rule, ignoring case.
+func (r FileRule) matches(name string) bool {
+	matched, err := filepath.Match(strings.ToLower(r.Pattern), strings.ToLower(name))
+	return err == nil && matched
+}
+
+// isExact reports whether the rule names a single file rather than a glob.
+func (r FileRule) isExact() bool {
+	return !strings.ContainsAny(r.Pattern, "*?[")
+}
+
+// fileRuleMatch is a workspace file selected by a rule.
+type fileRuleMatch struct {
+	name string
+	info os.FileInfo
+}
+
+// matchFileRules returns, for each rule, the regular files in dir it
+// selects. A file is only returned for the first rule that matches it, and
+// an exact-name rule selects at most one file, preferring the uppercase
+// name when case variants such as SOUL.md and soul.md both exist.
+func matchFileRules(dir string, rules []FileRule) ([][]fileRuleMatch, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	matches := make([][]fileRuleMatch, len(rules))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		for i, rule := range rules {
+			if !rule.matches(entry.Name()) {
+				continue
+			}
+			if rule.isExact() && len(matches[i]) > 0 {
+				break
+			}
+
+			// Stat follows symlinks, like the agent reading the file
+			info, err := os.Stat(filepath.Join(dir, entry.Name()))
+			if err != nil || !info.Mode().IsRegular() {
+				break
+			}
+			matches[i] = append(matches[i], fileRuleMatch{name: entry.Name(), info: info})
+			break
+		}
+	}
+
+	return matches, nil
+}
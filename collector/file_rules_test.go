@@ -0,0 +1,170 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateFileRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []FileRule
+		wantErr string
+	}{
+		{"defaults", defaultFileRules, ""},
+		{"globs", []FileRule{{Pattern: "*.md"}, {Pattern: "notes-?.txt"}, {Pattern: "[A-Z]*.md"}}, ""},
+		{"empty pattern", []FileRule{{Pattern: ""}}, "without pattern"},
+		{"subdirectory", []FileRule{{Pattern: "memory/*.md"}}, "workspace root only"},
+		{"windows separator", []FileRule{{Pattern: `memory\a.md`}}, "workspace root only"},
+		{"parent directory", []FileRule{{Pattern: "../SOUL.md"}}, "workspace root only"},
+		{"malformed glob", []FileRule{{Pattern: "[.md"}}, "syntax error"},
+		{"duplicate pattern", []FileRule{{Pattern: "SOUL.md"}, {Pattern: "SOUL.md", Required: true}}, `"SOUL.md": duplicates "SOUL.md"`},
+		{"case-insensitive duplicate", []FileRule{{Pattern: "SOUL.md"}, {Pattern: "soul.md"}}, `"soul.md": duplicates "SOUL.md"`},
+		{"duplicate glob", []FileRule{{Pattern: "*.MD"}, {Pattern: "AGENTS.md"}, {Pattern: "*.md"}}, `"*.md": duplicates "*.MD"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFileRules(tt.rules)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateFileRules() error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateFileRules() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadExporterConfigDuplicateFileRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	config := "files:\n  - pattern: SOUL.md\n    required: true\n  - pattern: soul.md\n"
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadExporterConfig(path); err == nil {
+		t.Error("LoadExporterConfig accepted case-insensitive duplicate file patterns")
+	}
+}
+
+func TestFileRuleMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"SOUL.md", "SOUL.md", true},
+		{"SOUL.md", "soul.md", true},
+		{"soul.md", "Soul.MD", true},
+		{"SOUL.md", "SOUL.md.bak", false},
+		{"*.md", "NOTES.MD", true},
+		{"*.md", "notes.txt", false},
+		{"context?.md", "CONTEXT1.md", true},
+		{"context?.md", "context.md", false},
+		{"[a-c]*.md", "Beta.md", true},
+	}
+
+	for _, tt := range tests {
+		if got := (FileRule{Pattern: tt.pattern}).matches(tt.name); got != tt.want {
+			t.Errorf("FileRule{%q}.matches(%q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMatchFileRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		dirs  []string
+		rules []FileRule
+		want  [][]string
+	}{
+		{
+			name:  "exact names",
+			files: []string{"AGENTS.md", "SOUL.md", "other.md"},
+			rules: []FileRule{{Pattern: "AGENTS.md"}, {Pattern: "SOUL.md"}, {Pattern: "TOOLS.md"}},
+			want:  [][]string{{"AGENTS.md"}, {"SOUL.md"}, nil},
+		},
+		{
+			name:  "exact name prefers uppercase",
+			files: []string{"soul.md", "SOUL.md", "Soul.md"},
+			rules: []FileRule{{Pattern: "SOUL.md"}},
+			want:  [][]string{{"SOUL.md"}},
+		},
+		{
+			name:  "lowercase pattern still prefers uppercase file",
+			files: []string{"soul.md", "SOUL.md"},
+			rules: []FileRule{{Pattern: "soul.md"}},
+			want:  [][]string{{"SOUL.md"}},
+		},
+		{
+			name:  "first matching rule wins",
+			files: []string{"AGENTS.md", "NOTES.md"},
+			rules: []FileRule{{Pattern: "AGENTS.md"}, {Pattern: "*.md"}},
+			want:  [][]string{{"AGENTS.md"}, {"NOTES.md"}},
+		},
+		{
+			name:  "case variant of an exact match is not picked up by a later glob",
+			files: []string{"SOUL.md", "soul.md", "USER.md"},
+			rules: []FileRule{{Pattern: "SOUL.md"}, {Pattern: "*.md"}},
+			want:  [][]string{{"SOUL.md"}, {"USER.md"}},
+		},
+		{
+			name:  "directories are skipped",
+			files: []string{"a.md"},
+			dirs:  []string{"b.md"},
+			rules: []FileRule{{Pattern: "*.md"}},
+			want:  [][]string{{"a.md"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, name := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, name := range tt.dirs {
+				if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			matches, err := matchFileRules(dir, tt.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([][]string, len(matches))
+			for i, ruleMatches := range matches {
+				for _, match := range ruleMatches {
+					got[i] = append(got[i], match.name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchFileRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchFileRulesMissingDir(t *testing.T) {
+	matches, err := matchFileRules(filepath.Join(t.TempDir(), "missing"), defaultFileRules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ruleMatches := range matches {
+		if len(ruleMatches) != 0 {
+			t.Errorf("rule %q matched %d files in a missing directory", defaultFileRules[i].Pattern, len(ruleMatches))
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+// Longest time a cached worktree status is reused while HEAD and the index
+// are unchanged
+const gitStatusMaxAge = 5 * time.Minute
+
+// Most commits counted on either side of the upstream comparison
+const gitAheadBehindLimit = 10000
+
+// gitStatus summarizes the git repository of a workspace.
+type gitStatus struct {
+	isRepo bool
+	// branch is "HEAD" when detached
+	branch string
+	// headTime is zero for a repository without commits
+	headTime  float64
+	changed   float64
+	untracked float64
+
+	// upstream is empty when the branch tracks no upstream ref
+	upstream string
+	ahead    float64
+	behind   float64
+}
+
+// gitWorktreeStatus is a cached worktree status of a repository.
+type gitWorktreeStatus struct {
+	head         plumbing.Hash
+	indexModTime time.Time
+	checkedAt    time.Time
+	changed      float64
+	untracked    float64
+}
+
+// gitStatusCache holds the last worktree status of each repository, since
+// computing it hashes every tracked file. It is safe for concurrent use.
+type gitStatusCache struct {
+	mu      sync.Mutex
+	entries map[string]gitWorktreeStatus
+	pending map[string]*gitStatusRun
+}
+
+// gitStatusRun is a worktree status computation in progress.
+type gitStatusRun struct {
+	// done is closed once status and err are set
+	done   chan struct{}
+	status gitWorktreeStatus
+	err    error
+}
+
+func newGitStatusCache() *gitStatusCache {
+	return &gitStatusCache{
+		entries: make(map[string]gitWorktreeStatus),
+		pending: make(map[string]*gitStatusRun),
+	}
+}
+
+// readGitStatus reads the git repository rooted at dir, if any, without
+// running git. Changed files include staged and unstaged changes; untracked
+// files honour .gitignore. The worktree status is taken from cache while
+// HEAD and the index are unchanged, for at most gitStatusMaxAge.
+func readGitStatus(ctx context.Context, dir string, cache *gitStatusCache) (gitStatus, error) {
+	var status gitStatus
+
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		if errors.Is(err, git.ErrRepositoryNotExists) {
+			return status, nil
+		}
+		return status, err
+	}
+	status.isRepo = true
+
+	head, err := repo.Head()
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		// No commits yet; HEAD still names the unborn branch
+		if ref, err := repo.Reference(plumbing.HEAD, false); err == nil {
+			status.branch = ref.Target().Short()
+		}
+	case err != nil:
+		return status, err
+	default:
+		status.branch = "HEAD"
+		if head.Name().IsBranch() {
+			status.branch = head.Name().Short()
+		}
+
+		commit, err := repo.CommitObject(head.Hash())
+		if err != nil {
+			return status, err
+		}
+		status.headTime = float64(commit.Committer.When.Unix())
+
+		if err := readGitUpstream(ctx, repo, head, commit, &status); err != nil {
+			return status, err
+		}
+	}
+
+	current := gitWorktreeStatus{checkedAt: time.Now()}
+	if head != nil {
+		current.head = head.Hash()
+	}
+	if storage, ok := repo.Storer.(*filesystem.Storage); ok {
+		if info, err := storage.Filesystem().Stat("index"); err == nil {
+			current.indexModTime = info.ModTime()
+		}
+	}
+
+	cache.mu.Lock()
+	cached, ok := cache.entries[dir]
+	cache.mu.Unlock()
+	if ok && cached.head == current.head && cached.indexModTime.Equal(current.indexModTime) && current.checkedAt.Sub(cached.checkedAt) < gitStatusMaxAge {
+		status.changed = cached.changed
+		status.untracked = cached.untracked
+		return status, nil
+	}
+
+	current, err = cache.compute(ctx, dir, repo, current)
+	if err != nil {
+		return status, err
+	}
+	status.changed = current.changed
+	status.untracked = current.untracked
+
+	return status, nil
+}
+
+// compute counts the changed and untracked files of the repository at dir
+// and caches the result. go-git cannot cancel a status computation, so it
+// runs on its own goroutine and compute stops waiting for it when ctx is
+// done; the result is still cached when it finishes. Only one computation
+// runs per repository, later callers wait for it.
+func (c *gitStatusCache) compute(ctx context.Context, dir string, repo *git.Repository, current gitWorktreeStatus) (gitWorktreeStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return current, err
+	}
+
+	c.mu.Lock()
+	run, running := c.pending[dir]
+	if !running {
+		run = &gitStatusRun{done: make(chan struct{})}
+		c.pending[dir] = run
+	}
+	c.mu.Unlock()
+
+	if !running {
+		go func() {
+			run.status, run.err = countWorktreeChanges(repo, current)
+
+			c.mu.Lock()
+			delete(c.pending, dir)
+			if run.err == nil {
+				c.entries[dir] = run.status
+			}
+			c.mu.Unlock()
+			close(run.done)
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		return current, ctx.Err()
+	case <-run.done:
+		return run.status, run.err
+	}
+}
+
+// countWorktreeChanges fills in the changed and untracked file counts of
+// status from the worktree of repo.
+func countWorktreeChanges(repo *git.Repository, status gitWorktreeStatus) (gitWorktreeStatus, error) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return status, err
+	}
+
+	files, err := worktree.Status()
+	if err != nil {
+		return status, err
+	}
+
+	for _, file := range files {
+		switch {
+		case file.Worktree == git.Untracked:
+			status.untracked++
+		case file.Staging != git.Unmodified || file.Worktree != git.Unmodified:
+			status.changed++
+		}
+	}
+
+	return status, nil
+}
+
+// readGitUpstream resolves the upstream of the checked out branch and counts
+// the commits HEAD is ahead of and behind it, from their merge base.
+func readGitUpstream(ctx context.Context, repo *git.Repository, head *plumbing.Reference, headCommit *object.Commit, status *gitStatus) error {
+	if !head.Name().IsBranch() {
+		return nil
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		return err
+	}
+
+	branch, ok := cfg.Branches[head.Name().Short()]
+	if !ok || branch.Remote == "" || branch.Merge == "" {
+		return nil
+	}
+
+	upstreamName := branch.Merge
+	if branch.Remote != "." {
+		upstreamName = plumbing.NewRemoteReferenceName(branch.Remote, branch.Merge.Short())
+	}
+
+	upstream, err := repo.Reference(upstreamName, true)
+	if err != nil {
+		// Upstream configured but never fetched
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil
+		}
+		return err
+	}
+	status.upstream = upstreamName.Short()
+
+	if upstream.Hash() == headCommit.Hash {
+		return nil
+	}
+
+	upstreamCommit, err := repo.CommitObject(upstream.Hash())
+	if err != nil {
+		return err
+	}
+
+	bases, err := headCommit.MergeBase(upstreamCommit)
+	if err != nil {
+		return err
+	}
+	baseHashes := make([]plumbing.Hash, 0, len(bases))
+	for _, base := range bases {
+		baseHashes = append(baseHashes, base.Hash)
+	}
+
+	if status.ahead, err = countGitCommits(ctx, headCommit, baseHashes); err != nil {
+		return err
+	}
+	status.behind, err = countGitCommits(ctx, upstreamCommit, baseHashes)
+
+	return err
+}
+
+// countGitCommits counts the commits reachable from from without passing
+// through any of the stop commits, up to gitAheadBehindLimit.
+func countGitCommits(ctx context.Context, from *object.Commit, stop []plumbing.Hash) (float64, error) {
+	commits := object.NewCommitPreorderIter(from, nil, stop)
+	defer commits.Close()
+
+	var count float64
+	for count < gitAheadBehindLimit {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		if _, err := commits.Next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
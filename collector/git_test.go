@@ -0,0 +1,173 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// gitTestCommit writes name and commits it on the checked out branch.
+func gitTestCommit(t *testing.T, repo *git.Repository, dir, name string, when time.Time) plumbing.Hash {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := worktree.Add(name); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := worktree.Commit(name, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: when},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hash
+}
+
+func TestReadGitStatusAheadBehind(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	base := gitTestCommit(t, repo, dir, "AGENTS.md", now.Add(-5*time.Hour))
+
+	// Upstream moves on by two commits from base
+	gitTestCommit(t, repo, dir, "remote1.md", now.Add(-4*time.Hour))
+	remote := gitTestCommit(t, repo, dir, "remote2.md", now.Add(-3*time.Hour))
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	upstream := plumbing.NewRemoteReferenceName("origin", head.Name().Short())
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(upstream, remote)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The local branch adds three commits on top of base
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(head.Name(), base)); err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := worktree.Reset(&git.ResetOptions{Commit: base, Mode: git.HardReset}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"local1.md", "local2.md", "local3.md"} {
+		gitTestCommit(t, repo, dir, name, now.Add(-2*time.Hour))
+	}
+
+	if err := repo.CreateBranch(&config.Branch{Name: head.Name().Short(), Remote: "origin", Merge: head.Name()}); err != nil {
+		t.Fatal(err)
+	}
+
+	// One unstaged change and one untracked file
+	if err := os.WriteFile(filepath.Join(dir, "AGENTS.md"), []byte("edited"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.md"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := newGitStatusCache()
+	status, err := readGitStatus(context.Background(), dir, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !status.isRepo || status.branch != head.Name().Short() || status.upstream != "origin/"+head.Name().Short() {
+		t.Errorf("status = %+v, want repository on %s tracking origin", status, head.Name().Short())
+	}
+	if status.ahead != 3 || status.behind != 2 {
+		t.Errorf("ahead, behind = %v, %v, want 3, 2", status.ahead, status.behind)
+	}
+	if status.changed != 1 || status.untracked != 1 {
+		t.Errorf("changed, untracked = %v, %v, want 1, 1", status.changed, status.untracked)
+	}
+
+	// The worktree status is reused while HEAD and the index are unchanged
+	if err := os.WriteFile(filepath.Join(dir, "more.md"), []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cached, err := readGitStatus(context.Background(), dir, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached.untracked != 1 {
+		t.Errorf("untracked = %v from cache, want 1", cached.untracked)
+	}
+
+	// A new commit invalidates the cache
+	gitTestCommit(t, repo, dir, "local4.md", now.Add(-time.Hour))
+	fresh, err := readGitStatus(context.Background(), dir, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.ahead != 4 || fresh.untracked != 2 {
+		t.Errorf("ahead, untracked = %v, %v after a commit, want 4, 2", fresh.ahead, fresh.untracked)
+	}
+}
+
+func TestReadGitStatusNotARepository(t *testing.T) {
+	status, err := readGitStatus(context.Background(), t.TempDir(), newGitStatusCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.isRepo {
+		t.Error("readGitStatus reported a repository in an empty directory")
+	}
+}
+
+func TestReadGitStatusCanceled(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitTestCommit(t, repo, dir, "AGENTS.md", time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := readGitStatus(ctx, dir, newGitStatusCache()); err == nil {
+		t.Error("readGitStatus with a canceled context returned no error")
+	}
+}
+
+func TestReadGitStatusStopsWaitingOnDeadline(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gitTestCommit(t, repo, dir, "AGENTS.md", time.Now())
+
+	// A status computation of the repository that never finishes
+	cache := newGitStatusCache()
+	cache.pending[dir] = &gitStatusRun{done: make(chan struct{})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := readGitStatus(ctx, dir, cache); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("readGitStatus while a computation is running returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,137 @@
+package collector
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Token the agent replies with when a heartbeat needs no attention.
+const heartbeatOKToken = "HEARTBEAT_OK"
+
+// Start of openclaw's default heartbeat prompt
+const defaultHeartbeatPromptPrefix = "Read HEARTBEAT.md"
+
+// Replies that are at most this long once the HEARTBEAT_OK token is removed are
+// treated as a silent acknowledgement, matching openclaw's ackMaxChars default.
+const heartbeatAckMaxChars = 300
+
+// agentHeartbeats accumulates heartbeat runs across all sessions of an agent.
+type agentHeartbeats struct {
+	runs     float64
+	acks     float64
+	messages float64
+	cost     float64
+	lastRun  time.Time
+}
+
+// heartbeatTracker follows the turns of a single transcript and attributes
+// assistant replies to the heartbeat prompt that triggered them.
+type heartbeatTracker struct {
+	stats *agentHeartbeats
+	// prompt is the configured heartbeat prompt, empty for the default
+	prompt    string
+	active    bool
+	lastReply string
+}
+
+// userMessage starts a new turn, closing any heartbeat turn in progress.
+func (t *heartbeatTracker) userMessage(text string, ts time.Time) {
+	t.finish()
+
+	if !isHeartbeatPrompt(text, t.prompt) {
+		return
+	}
+
+	t.active = true
+	t.stats.runs++
+	if ts.After(t.stats.lastRun) {
+		t.stats.lastRun = ts
+	}
+}
+
+// assistantMessage records a reply within the current turn.
+func (t *heartbeatTracker) assistantMessage(text string, cost float64) {
+	if !t.active {
+		return
+	}
+
+	t.stats.cost += cost
+	if strings.TrimSpace(text) != "" {
+		t.lastReply = text
+	}
+}
+
+// finish classifies the heartbeat turn in progress, if any.
+func (t *heartbeatTracker) finish() {
+	if !t.active {
+		return
+	}
+
+	if isHeartbeatAck(t.lastReply) {
+		t.stats.acks++
+	} else {
+		t.stats.messages++
+	}
+
+	t.active = false
+	t.lastReply = ""
+}
+
+// isHeartbeatPrompt reports whether a user message is a heartbeat poll: the
+// default prompt, which starts with "Read HEARTBEAT.md", or exactly the
+// configured agents.defaults.heartbeat.prompt. User messages that merely
+// mention HEARTBEAT_OK are not polls.
+func isHeartbeatPrompt(text, prompt string) bool {
+	text = strings.TrimSpace(text)
+	if prompt = strings.TrimSpace(prompt); prompt != "" && text == prompt {
+		return true
+	}
+	return strings.HasPrefix(text, defaultHeartbeatPromptPrefix)
+}
+
+// isHeartbeatAck reports whether a heartbeat reply is a silent acknowledgement
+// rather than a message that would be delivered to the user.
+func isHeartbeatAck(reply string) bool {
+	reply = strings.TrimSpace(reply)
+	if reply == "" {
+		return true
+	}
+
+	if !strings.HasPrefix(reply, heartbeatOKToken) && !strings.HasSuffix(reply, heartbeatOKToken) {
+		return false
+	}
+
+	rest := strings.TrimSpace(strings.ReplaceAll(reply, heartbeatOKToken, ""))
+	return len([]rune(rest)) <= heartbeatAckMaxChars
+}
+
+// messageText extracts the text of a transcript message, whose content is
+// either a plain string or a list of typed content blocks.
+func messageText(content json.RawMessage) string {
+	if len(content) == 0 {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(content, &text); err == nil {
+		return text
+	}
+
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(content, &blocks); err != nil {
+		return ""
+	}
+
+	var parts []string
+	for _, block := range blocks {
+		if block.Type == "text" && block.Text != "" {
+			parts = append(parts, block.Text)
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
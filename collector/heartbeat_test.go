@@ -0,0 +1,51 @@
+package collector
+
+import "testing"
+
+func TestIsHeartbeatPrompt(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		prompt string
+		want   bool
+	}{
+		{"default prompt", "Read HEARTBEAT.md if it exists. If nothing needs attention, reply HEARTBEAT_OK.", "", true},
+		{"default prompt with whitespace", "\n  Read HEARTBEAT.md and follow it.", "", true},
+		{"user mentions token", "What does HEARTBEAT_OK mean?", "", false},
+		{"user mentions token with custom prompt", "Why did you reply HEARTBEAT_OK?", "Check in. Reply HEARTBEAT_OK if idle.", false},
+		{"custom prompt", "Check in. Reply HEARTBEAT_OK if idle.", "Check in. Reply HEARTBEAT_OK if idle.", true},
+		{"custom prompt with whitespace", " Check in. \n", "Check in.", true},
+		{"custom prompt prefix only", "Check in. Also, what's the weather?", "Check in.", false},
+		{"default prompt with custom prompt configured", "Read HEARTBEAT.md if it exists.", "Check in.", true},
+		{"empty message", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isHeartbeatPrompt(tt.text, tt.prompt); got != tt.want {
+				t.Errorf("isHeartbeatPrompt(%q, %q) = %v, want %v", tt.text, tt.prompt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsHeartbeatAck(t *testing.T) {
+	tests := []struct {
+		reply string
+		want  bool
+	}{
+		{"", true},
+		{"HEARTBEAT_OK", true},
+		{"  HEARTBEAT_OK\n", true},
+		{"All quiet. HEARTBEAT_OK", true},
+		{"Your flight is delayed by two hours.", false},
+		{"HEARTBEAT_OK is the token I use", true},
+		{"I mentioned HEARTBEAT_OK in passing", false},
+	}
+
+	for _, tt := range tests {
+		if got := isHeartbeatAck(tt.reply); got != tt.want {
+			t.Errorf("isHeartbeatAck(%q) = %v, want %v", tt.reply, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Maximum length of identity label values
+const identityMaxValueLength = 100
+
+// identityFieldPattern matches an IDENTITY.md field line such as
+// "- **Name:** Clawd", "**Role**: assistant" or "Vibe: calm".
+var identityFieldPattern = regexp.MustCompile(`^\s*(?:[-*+]\s+)?[*_]{0,2}([A-Za-z][A-Za-z ]*?)[*_]{0,2}\s*:[*_]{0,2}\s*(.*)$`)
+
+// identityFields maps IDENTITY.md field names to the identity they set.
+var identityFields = map[string]string{
+	"name":     "name",
+	"creature": "creature",
+	"role":     "creature",
+	"vibe":     "vibe",
+	"emoji":    "emoji",
+}
+
+// agentIdentity holds the fields of a workspace's IDENTITY.md.
+type agentIdentity struct {
+	found    bool
+	name     string
+	creature string
+	vibe     string
+	emoji    string
+}
+
+// readAgentIdentity parses IDENTITY.md in dir. A missing file yields an
+// identity with found unset.
+func readAgentIdentity(dir string) (agentIdentity, error) {
+	for _, name := range []string{"IDENTITY.md", "identity.md"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return agentIdentity{}, err
+		}
+
+		identity := parseAgentIdentity(string(data))
+		identity.found = true
+		return identity, nil
+	}
+
+	return agentIdentity{}, nil
+}
+
+// parseAgentIdentity extracts the identity fields from IDENTITY.md. The
+// first value of each field wins; template placeholders are left empty.
+func parseAgentIdentity(text string) agentIdentity {
+	var identity agentIdentity
+
+	for _, line := range strings.Split(text, "\n") {
+		match := identityFieldPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		field, ok := identityFields[strings.ToLower(strings.TrimSpace(match[1]))]
+		if !ok {
+			continue
+		}
+
+		value := identityValue(match[2])
+		switch {
+		case field == "name" && identity.name == "":
+			identity.name = value
+		case field == "creature" && identity.creature == "":
+			identity.creature = value
+		case field == "vibe" && identity.vibe == "":
+			identity.vibe = value
+		case field == "emoji" && identity.emoji == "":
+			identity.emoji = value
+		}
+	}
+
+	return identity
+}
+
+// identityValue strips markdown emphasis from a field value and drops
+// unfilled template placeholders such as "*(pick something you like)*".
+func identityValue(value string) string {
+	value = strings.TrimSpace(strings.Trim(strings.TrimSpace(value), "*_`"))
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		return ""
+	}
+
+	if utf8.RuneCountInString(value) > identityMaxValueLength {
+		value = string([]rune(value)[:identityMaxValueLength])
+	}
+
+	return value
+}
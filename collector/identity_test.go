@@ -0,0 +1,66 @@
+package collector
+
+import (
+	"strings"
+	"testing"
+)
+
+// identityTemplate is the stock IDENTITY.md created by openclaw.
+const identityTemplate = `# IDENTITY.md - Who Am I?
+
+_Fill this in during your first conversation. Make it yours._
+
+- **Name:**
+  _(pick something you like)_
+- **Creature:**
+  _(AI? robot? familiar? ghost in the machine? something weirder?)_
+- **Vibe:**
+  _(how do you come across? sharp? warm? chaotic? calm?)_
+- **Emoji:**
+  _(your signature — pick one that feels right)_
+- **Avatar:**
+  _(workspace-relative path, http(s) URL, or data URI)_
+
+---
+
+This isn't just metadata. It's the start of figuring out who you are.
+`
+
+func TestParseAgentIdentity(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want agentIdentity
+	}{
+		{"stock template", identityTemplate, agentIdentity{}},
+		{
+			"filled template",
+			"# IDENTITY.md - Who Am I?\n\n- **Name:** Clawd\n- **Creature:** space lobster\n- **Vibe:** calm\n- **Emoji:** 🦞\n- **Avatar:** avatars/clawd.png\n",
+			agentIdentity{name: "Clawd", creature: "space lobster", vibe: "calm", emoji: "🦞"},
+		},
+		{
+			"CRLF line endings",
+			"- **Name:** Clawd\r\n- **Vibe:** calm\r\n",
+			agentIdentity{name: "Clawd", vibe: "calm"},
+		},
+		{
+			"emphasis variants",
+			"**Name**: Clawd\n* __Creature:__ lobster\n+ Vibe: *calm*\n_Emoji_: `🦞`\n",
+			agentIdentity{name: "Clawd", creature: "lobster", vibe: "calm", emoji: "🦞"},
+		},
+		{"role is an alias of creature", "- **Role:** assistant\n", agentIdentity{creature: "assistant"}},
+		{"first value wins", "- **Role:** assistant\n- **Creature:** lobster\n- **Name:** Clawd\n- **Name:** Molty\n", agentIdentity{name: "Clawd", creature: "assistant"}},
+		{"inline placeholder", "- **Name:** *(pick something you like)*\n- **Vibe:** calm\n", agentIdentity{vibe: "calm"}},
+		{"case-insensitive field names", "NAME: Clawd\nvibe: calm\n", agentIdentity{name: "Clawd", vibe: "calm"}},
+		{"unknown fields", "- **Avatar:** clawd.png\n- **Pronouns:** they\n", agentIdentity{}},
+		{"long value truncated by runes", "Name: " + strings.Repeat("é", 150) + "\n", agentIdentity{name: strings.Repeat("é", identityMaxValueLength)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseAgentIdentity(tt.text); got != tt.want {
+				t.Errorf("parseAgentIdentity = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
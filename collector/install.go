@@ -0,0 +1,276 @@
+package collector
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Name of the openclaw npm package
+const openclawPackageName = "openclaw"
+
+// Global node_modules directories of system-wide npm and Homebrew installs
+var systemNodeModulesDirs = []string{
+	"/opt/homebrew/lib/node_modules",
+	"/usr/local/lib/node_modules",
+	"/usr/lib/node_modules",
+}
+
+// openclawInstall is a resolved openclaw package directory, its version and
+// the method that found it: path, npm_prefix, nvm, pnpm, bun or system.
+type openclawInstall struct {
+	dir     string
+	version string
+	method  string
+}
+
+// resolveSystemSkillsDir returns the system skills directory of install and
+// how it was resolved. OPENCLAW_SKILLS_DIR takes precedence over the package
+// directory. The method is "none" when no directory was found.
+func resolveSystemSkillsDir(install openclawInstall, found bool) (string, string) {
+	if systemSkillsDir := os.Getenv("OPENCLAW_SKILLS_DIR"); systemSkillsDir != "" {
+		return systemSkillsDir, "env"
+	}
+
+	if found {
+		skillsDir := filepath.Join(install.dir, "skills")
+		if info, err := os.Stat(skillsDir); err == nil && info.IsDir() {
+			return skillsDir, install.method
+		}
+	}
+
+	return "", "none"
+}
+
+// resolveOpenclawInstall locates the installed openclaw package, trying the
+// openclaw binary on PATH first and then the global package directories of
+// npm, nvm, pnpm, bun and system-wide installs.
+func resolveOpenclawInstall() (openclawInstall, bool) {
+	if dir, ok := installFromPath(); ok {
+		return newOpenclawInstall(dir, "path"), true
+	}
+
+	home := os.Getenv("HOME")
+	candidates := []struct {
+		method string
+		dirs   []string
+	}{
+		{"npm_prefix", npmPrefixNodeModules(home)},
+		{"nvm", nvmNodeModules(home)},
+		{"pnpm", pnpmNodeModules(home)},
+		{"bun", bunNodeModules(home)},
+		{"system", systemNodeModulesDirs},
+	}
+
+	for _, candidate := range candidates {
+		for _, nodeModules := range candidate.dirs {
+			dir := filepath.Join(nodeModules, openclawPackageName)
+			if isOpenclawPackage(dir) {
+				return newOpenclawInstall(dir, candidate.method), true
+			}
+		}
+	}
+
+	return openclawInstall{}, false
+}
+
+func newOpenclawInstall(dir, method string) openclawInstall {
+	install := openclawInstall{dir: dir, method: method}
+	if pkg, err := readPackageJSON(dir); err == nil {
+		install.version = pkg.Version
+	}
+	return install
+}
+
+// installFromPath follows the openclaw binary on PATH, usually a symlink into
+// the package, up to the package root.
+func installFromPath() (string, bool) {
+	bin, err := exec.LookPath(openclawPackageName)
+	if err != nil {
+		return "", false
+	}
+
+	resolved, err := filepath.EvalSymlinks(bin)
+	if err != nil {
+		return "", false
+	}
+
+	for dir := filepath.Dir(resolved); ; dir = filepath.Dir(dir) {
+		if isOpenclawPackage(dir) {
+			return dir, true
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+
+	// Wrapper scripts (e.g. nvm shims) live in <prefix>/bin
+	dir := filepath.Join(filepath.Dir(filepath.Dir(resolved)), "lib", "node_modules", openclawPackageName)
+	if isOpenclawPackage(dir) {
+		return dir, true
+	}
+
+	return "", false
+}
+
+// isOpenclawPackage reports whether dir holds the openclaw package.json.
+func isOpenclawPackage(dir string) bool {
+	pkg, err := readPackageJSON(dir)
+	return err == nil && pkg.Name == openclawPackageName
+}
+
+// packageJSON holds the package.json fields the exporter reads.
+type packageJSON struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+func readPackageJSON(dir string) (packageJSON, error) {
+	var pkg packageJSON
+
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return pkg, err
+	}
+
+	err = json.Unmarshal(data, &pkg)
+	return pkg, err
+}
+
+// npmPrefixNodeModules returns the global node_modules of the configured npm
+// prefix, from the environment or ~/.npmrc.
+func npmPrefixNodeModules(home string) []string {
+	prefix := os.Getenv("NPM_CONFIG_PREFIX")
+	if prefix == "" {
+		prefix = os.Getenv("npm_config_prefix")
+	}
+	if prefix == "" && home != "" {
+		prefix = npmrcPrefix(filepath.Join(home, ".npmrc"))
+	}
+	if prefix == "" {
+		return nil
+	}
+
+	prefix = expandHome(prefix)
+	if runtime.GOOS == "windows" {
+		return []string{filepath.Join(prefix, "node_modules")}
+	}
+	return []string{filepath.Join(prefix, "lib", "node_modules")}
+}
+
+// npmrcPrefix reads the prefix setting from an .npmrc file.
+func npmrcPrefix(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if ok && strings.TrimSpace(key) == "prefix" {
+			return strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+
+	return ""
+}
+
+// nvmNodeModules returns the global node_modules of every nvm-managed Node
+// version, newest first.
+func nvmNodeModules(home string) []string {
+	nvmDir := os.Getenv("NVM_DIR")
+	if nvmDir == "" && home != "" {
+		nvmDir = filepath.Join(home, ".nvm")
+	}
+	if nvmDir == "" {
+		return nil
+	}
+
+	versions, err := filepath.Glob(filepath.Join(nvmDir, "versions", "node", "v*"))
+	if err != nil {
+		return nil
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareNodeVersions(filepath.Base(versions[i]), filepath.Base(versions[j])) > 0
+	})
+
+	dirs := make([]string, 0, len(versions))
+	for _, version := range versions {
+		dirs = append(dirs, filepath.Join(version, "lib", "node_modules"))
+	}
+
+	return dirs
+}
+
+// pnpmNodeModules returns the global node_modules of pnpm's global store.
+func pnpmNodeModules(home string) []string {
+	var roots []string
+	if pnpmHome := os.Getenv("PNPM_HOME"); pnpmHome != "" {
+		roots = append(roots, pnpmHome)
+	}
+	if home != "" {
+		roots = append(roots,
+			filepath.Join(home, ".local", "share", "pnpm"),
+			filepath.Join(home, "Library", "pnpm"),
+		)
+	}
+
+	var dirs []string
+	for _, root := range roots {
+		// Layout is <root>/global/<store version>/node_modules
+		matches, err := filepath.Glob(filepath.Join(root, "global", "*", "node_modules"))
+		if err != nil {
+			continue
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+		dirs = append(dirs, matches...)
+	}
+
+	return dirs
+}
+
+// bunNodeModules returns the global node_modules of bun.
+func bunNodeModules(home string) []string {
+	bunDir := os.Getenv("BUN_INSTALL")
+	if bunDir == "" && home != "" {
+		bunDir = filepath.Join(home, ".bun")
+	}
+	if bunDir == "" {
+		return nil
+	}
+
+	return []string{filepath.Join(bunDir, "install", "global", "node_modules")}
+}
+
+// compareNodeVersions compares "vMAJOR.MINOR.PATCH" version strings
+// numerically, returning -1, 0 or 1.
+func compareNodeVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var na, nb int
+		if i < len(partsA) {
+			na, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			nb, _ = strconv.Atoi(partsB[i])
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,240 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCompareNodeVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v20.11.1", "v20.11.1", 0},
+		{"v10.0.0", "v9.11.2", 1},
+		{"v9.11.2", "v10.0.0", -1},
+		{"v20.9.0", "v20.10.0", -1},
+		{"v20.10.1", "v20.10.0", 1},
+		{"v20", "v20.0.0", 0},
+		{"v20.1", "v20.0.5", 1},
+		{"v20", "v20.0.1", -1},
+		{"20.1.0", "v20.1.0", 0},
+	}
+
+	for _, tt := range tests {
+		if got := compareNodeVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareNodeVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNpmrcPrefix(t *testing.T) {
+	tests := []struct {
+		name  string
+		npmrc string
+		want  string
+	}{
+		{"prefix", "prefix=/opt/npm\n", "/opt/npm"},
+		{"spaces around equals", "registry = https://registry.npmjs.org/\nprefix = ~/.npm-global\n", "~/.npm-global"},
+		{"quoted", `prefix="/opt/npm global"` + "\n", "/opt/npm global"},
+		{"first prefix wins", "prefix=/first\nprefix=/second\n", "/first"},
+		{"no prefix", "registry=https://registry.npmjs.org/\n", ""},
+		{"similar key", "prefixes=/opt/npm\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".npmrc")
+			if err := os.WriteFile(path, []byte(tt.npmrc), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if got := npmrcPrefix(path); got != tt.want {
+				t.Errorf("npmrcPrefix = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := npmrcPrefix(filepath.Join(t.TempDir(), ".npmrc")); got != "" {
+		t.Errorf("npmrcPrefix of a missing file = %q, want empty", got)
+	}
+}
+
+func TestResolveOpenclawInstall(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("package layouts differ on Windows")
+	}
+
+	tests := []struct {
+		name       string
+		packages   []string
+		wantDir    string
+		wantMethod string
+	}{
+		{
+			"npm prefix from npmrc",
+			[]string{".npm-global/lib/node_modules/openclaw"},
+			".npm-global/lib/node_modules/openclaw",
+			"npm_prefix",
+		},
+		{
+			"newest nvm version",
+			[]string{
+				".nvm/versions/node/v9.11.2/lib/node_modules/openclaw",
+				".nvm/versions/node/v10.0.0/lib/node_modules/openclaw",
+				".nvm/versions/node/v22.1.0/lib/node_modules/other",
+			},
+			".nvm/versions/node/v10.0.0/lib/node_modules/openclaw",
+			"nvm",
+		},
+		{
+			"pnpm global store",
+			[]string{".local/share/pnpm/global/5/node_modules/openclaw"},
+			".local/share/pnpm/global/5/node_modules/openclaw",
+			"pnpm",
+		},
+		{
+			"bun",
+			[]string{".bun/install/global/node_modules/openclaw"},
+			".bun/install/global/node_modules/openclaw",
+			"bun",
+		},
+		{
+			"nvm before pnpm and bun",
+			[]string{
+				".bun/install/global/node_modules/openclaw",
+				".local/share/pnpm/global/5/node_modules/openclaw",
+				".nvm/versions/node/v20.0.0/lib/node_modules/openclaw",
+			},
+			".nvm/versions/node/v20.0.0/lib/node_modules/openclaw",
+			"nvm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("PATH", t.TempDir())
+			for _, name := range []string{"NPM_CONFIG_PREFIX", "npm_config_prefix", "NVM_DIR", "PNPM_HOME", "BUN_INSTALL"} {
+				t.Setenv(name, "")
+			}
+			if err := os.WriteFile(filepath.Join(home, ".npmrc"), []byte("prefix=~/.npm-global\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, dir := range tt.packages {
+				writeTestPackage(t, filepath.Join(home, dir), filepath.Base(dir))
+			}
+
+			install, ok := resolveOpenclawInstall()
+			if !ok {
+				t.Fatal("resolveOpenclawInstall found no install")
+			}
+			if want := filepath.Join(home, tt.wantDir); install.dir != want || install.method != tt.wantMethod {
+				t.Errorf("resolveOpenclawInstall = %s (%s), want %s (%s)", install.dir, install.method, want, tt.wantMethod)
+			}
+			if install.version != "1.2.3" {
+				t.Errorf("version = %q, want 1.2.3", install.version)
+			}
+		})
+	}
+}
+
+func TestInstallFromPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need privileges on Windows")
+	}
+
+	t.Run("symlink into the package", func(t *testing.T) {
+		prefix := t.TempDir()
+		pkg := filepath.Join(prefix, "lib", "node_modules", "openclaw")
+		writeTestPackage(t, pkg, "openclaw")
+		writeTestExecutable(t, filepath.Join(pkg, "dist", "openclaw.mjs"))
+
+		bin := filepath.Join(prefix, "bin")
+		if err := os.MkdirAll(bin, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(filepath.Join(pkg, "dist", "openclaw.mjs"), filepath.Join(bin, "openclaw")); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("PATH", bin)
+
+		if dir, ok := installFromPath(); !ok || !sameTestPath(t, dir, pkg) {
+			t.Errorf("installFromPath = %q, %v, want %q", dir, ok, pkg)
+		}
+	})
+
+	t.Run("wrapper script in the prefix", func(t *testing.T) {
+		prefix := t.TempDir()
+		pkg := filepath.Join(prefix, "lib", "node_modules", "openclaw")
+		writeTestPackage(t, pkg, "openclaw")
+		writeTestExecutable(t, filepath.Join(prefix, "bin", "openclaw"))
+		t.Setenv("PATH", filepath.Join(prefix, "bin"))
+
+		if dir, ok := installFromPath(); !ok || !sameTestPath(t, dir, pkg) {
+			t.Errorf("installFromPath = %q, %v, want %q", dir, ok, pkg)
+		}
+	})
+
+	t.Run("binary of another package", func(t *testing.T) {
+		prefix := t.TempDir()
+		writeTestPackage(t, filepath.Join(prefix, "lib", "node_modules", "openclaw"), "not-openclaw")
+		writeTestExecutable(t, filepath.Join(prefix, "bin", "openclaw"))
+		t.Setenv("PATH", filepath.Join(prefix, "bin"))
+
+		if dir, ok := installFromPath(); ok {
+			t.Errorf("installFromPath = %q, want no install", dir)
+		}
+	})
+
+	t.Run("not on PATH", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		if dir, ok := installFromPath(); ok {
+			t.Errorf("installFromPath = %q, want no install", dir)
+		}
+	})
+}
+
+// writeTestPackage writes a package.json named name at version 1.2.3 to dir.
+func writeTestPackage(t *testing.T, dir, name string) {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	pkg := `{"name": "` + name + `", "version": "1.2.3"}`
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(pkg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeTestExecutable writes an empty shell script to path.
+func writeTestExecutable(t *testing.T, path string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sameTestPath compares paths after resolving symlinks in the temp dir.
+func sameTestPath(t *testing.T, a, b string) bool {
+	t.Helper()
+
+	resolvedA, err := filepath.EvalSymlinks(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolvedB, err := filepath.EvalSymlinks(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resolvedA == resolvedB
+}
@@ -0,0 +1,315 @@
+package collector
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// json5ToJSON rewrites a JSON5 document such as openclaw.json into strict
+// JSON: comments are dropped, trailing commas removed, identifier keys and
+// single-quoted strings are double-quoted, string escapes and line
+// continuations are translated, and numbers are normalised (hex, leading
+// '+', leading or trailing decimal points). Infinity and NaN are not
+// representable in JSON and become null.
+func json5ToJSON(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	out.Grow(len(data))
+
+	src := []rune(string(data))
+	n := len(src)
+
+	for i := 0; i < n; i++ {
+		ch := src[i]
+
+		switch {
+		case ch == '"' || ch == '\'':
+			end, err := writeJSON5String(&out, src, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+
+		case ch == '/' && i+1 < n && src[i+1] == '/':
+			for i < n && !isJSON5LineTerminator(src[i]) {
+				i++
+			}
+			if i < n {
+				out.WriteRune('\n')
+			}
+
+		case ch == '/' && i+1 < n && src[i+1] == '*':
+			j := i + 2
+			for j+1 < n && (src[j] != '*' || src[j+1] != '/') {
+				j++
+			}
+			if j+1 >= n {
+				return nil, fmt.Errorf("unterminated block comment")
+			}
+			i = j + 1
+
+		case ch == ',':
+			// Drop the comma if the next significant token closes a container
+			if next := nextSignificant(src, i+1); next < n && (src[next] == '}' || src[next] == ']') {
+				continue
+			}
+			out.WriteRune(ch)
+
+		case isJSON5NumberStart(src, i):
+			end, err := writeJSON5Number(&out, src, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+
+		case isIdentifierStart(ch):
+			j := i
+			for j < n && isIdentifierPart(src[j]) {
+				j++
+			}
+			word := string(src[i:j])
+			switch next := nextSignificant(src, j); {
+			case next < n && src[next] == ':':
+				fmt.Fprintf(&out, "%q", word)
+			case word == "Infinity" || word == "NaN":
+				// Not representable in JSON; treat as null
+				out.WriteString("null")
+			default:
+				out.WriteString(word)
+			}
+			i = j - 1
+
+		case ch == '\uFEFF' || (unicode.IsSpace(ch) && !strings.ContainsRune(" \t\n\r", ch)):
+			// JSON5 whitespace that JSON does not accept
+			out.WriteRune(' ')
+
+		default:
+			out.WriteRune(ch)
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+// writeJSON5String writes the string literal starting at src[start] as a
+// double-quoted JSON string and returns the index of its closing quote.
+func writeJSON5String(out *bytes.Buffer, src []rune, start int) (int, error) {
+	quote := src[start]
+	out.WriteRune('"')
+
+	for i := start + 1; i < len(src); i++ {
+		ch := src[i]
+		switch {
+		case ch == '\\' && i+1 < len(src):
+			i++
+			next := src[i]
+			switch next {
+			case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+				out.WriteRune('\\')
+				out.WriteRune(next)
+			case 'u':
+				if i+4 >= len(src) || !allHexDigits(src[i+1:i+5]) {
+					return 0, fmt.Errorf("invalid unicode escape in string literal")
+				}
+				out.WriteString(`\u`)
+				out.WriteString(string(src[i+1 : i+5]))
+				i += 4
+			case 'x':
+				if i+2 >= len(src) || !allHexDigits(src[i+1:i+3]) {
+					return 0, fmt.Errorf("invalid hex escape in string literal")
+				}
+				out.WriteString(`\u00`)
+				out.WriteString(string(src[i+1 : i+3]))
+				i += 2
+			case 'v':
+				out.WriteString(`\u000b`)
+			case '0':
+				out.WriteString(`\u0000`)
+			case '\r':
+				// Line continuation; \r\n counts as one terminator
+				if i+1 < len(src) && src[i+1] == '\n' {
+					i++
+				}
+			case '\n', '\u2028', '\u2029':
+				// Line continuation
+			default:
+				// Any other escaped character stands for itself
+				out.WriteRune(next)
+			}
+		case ch == quote:
+			out.WriteRune('"')
+			return i, nil
+		case ch == '"':
+			out.WriteString(`\"`)
+		case ch == '\n':
+			out.WriteString(`\n`)
+		case ch == '\r':
+			out.WriteString(`\r`)
+		case ch == '\t':
+			out.WriteString(`\t`)
+		default:
+			out.WriteRune(ch)
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated string literal")
+}
+
+// isJSON5NumberStart reports whether a numeric literal, optionally signed,
+// starts at src[i]. Unsigned Infinity and NaN are handled as identifiers.
+func isJSON5NumberStart(src []rune, i int) bool {
+	if src[i] == '+' || src[i] == '-' {
+		i++
+		if i >= len(src) {
+			return false
+		}
+		if hasRunePrefix(src[i:], "Infinity") || hasRunePrefix(src[i:], "NaN") {
+			return true
+		}
+	}
+	if i >= len(src) {
+		return false
+	}
+
+	return unicode.IsDigit(src[i]) || (src[i] == '.' && i+1 < len(src) && unicode.IsDigit(src[i+1]))
+}
+
+// writeJSON5Number writes the numeric literal starting at src[start] as a
+// JSON number and returns the index of its last rune.
+func writeJSON5Number(out *bytes.Buffer, src []rune, start int) (int, error) {
+	i := start
+	sign := ""
+	if src[i] == '+' || src[i] == '-' {
+		if src[i] == '-' {
+			sign = "-"
+		}
+		i++
+	}
+
+	for _, word := range []string{"Infinity", "NaN"} {
+		if hasRunePrefix(src[i:], word) {
+			out.WriteString("null")
+			return i + len(word) - 1, nil
+		}
+	}
+
+	if src[i] == '0' && i+1 < len(src) && (src[i+1] == 'x' || src[i+1] == 'X') {
+		j := i + 2
+		for j < len(src) && isHexDigit(src[j]) {
+			j++
+		}
+		value, ok := new(big.Int).SetString(string(src[i+2:j]), 16)
+		if !ok {
+			return 0, fmt.Errorf("invalid hex number")
+		}
+		out.WriteString(sign + value.String())
+		return j - 1, nil
+	}
+
+	j := i
+	digits := func() string {
+		from := j
+		for j < len(src) && unicode.IsDigit(src[j]) {
+			j++
+		}
+		return string(src[from:j])
+	}
+
+	integer := digits()
+	fraction := ""
+	if j < len(src) && src[j] == '.' {
+		j++
+		fraction = digits()
+	}
+	exponent := ""
+	if j < len(src) && (src[j] == 'e' || src[j] == 'E') {
+		j++
+		expSign := ""
+		if j < len(src) && (src[j] == '+' || src[j] == '-') {
+			expSign = string(src[j])
+			j++
+		}
+		expDigits := digits()
+		if expDigits == "" {
+			return 0, fmt.Errorf("invalid number exponent")
+		}
+		exponent = "e" + expSign + expDigits
+	}
+
+	if integer == "" {
+		integer = "0"
+	}
+	out.WriteString(sign + integer)
+	if fraction != "" {
+		out.WriteString("." + fraction)
+	}
+	out.WriteString(exponent)
+
+	return j - 1, nil
+}
+
+// nextSignificant returns the index of the next rune after whitespace and
+// comments, or len(src) if there is none.
+func nextSignificant(src []rune, i int) int {
+	n := len(src)
+	for i < n {
+		switch {
+		case unicode.IsSpace(src[i]) || src[i] == '\uFEFF':
+			i++
+		case src[i] == '/' && i+1 < n && src[i+1] == '/':
+			for i < n && !isJSON5LineTerminator(src[i]) {
+				i++
+			}
+		case src[i] == '/' && i+1 < n && src[i+1] == '*':
+			i += 2
+			for i+1 < n && (src[i] != '*' || src[i+1] != '/') {
+				i++
+			}
+			i += 2
+		default:
+			return i
+		}
+	}
+	return n
+}
+
+func isJSON5LineTerminator(ch rune) bool {
+	return ch == '\n' || ch == '\r' || ch == '\u2028' || ch == '\u2029'
+}
+
+func hasRunePrefix(src []rune, prefix string) bool {
+	p := []rune(prefix)
+	if len(src) < len(p) {
+		return false
+	}
+	for i, ch := range p {
+		if src[i] != ch {
+			return false
+		}
+	}
+	// Reject identifiers such as "Infinityx" or "NaNa"
+	return len(src) == len(p) || !isIdentifierPart(src[len(p)])
+}
+
+func allHexDigits(src []rune) bool {
+	for _, ch := range src {
+		if !isHexDigit(ch) {
+			return false
+		}
+	}
+	return true
+}
+
+func isIdentifierStart(ch rune) bool {
+	return ch == '_' || ch == '$' || unicode.IsLetter(ch)
+}
+
+func isIdentifierPart(ch rune) bool {
+	return isIdentifierStart(ch) || unicode.IsDigit(ch)
+}
+
+func isHexDigit(ch rune) bool {
+	return (ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'f') || (ch >= 'A' && ch <= 'F')
+}
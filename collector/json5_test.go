@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSON5ToJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  any
+	}{
+		{"plain JSON", `{"a": [1, "b", true, null]}`, map[string]any{"a": []any{1.0, "b", true, nil}}},
+		{"line comment", "{\n  // comment\n  a: 1, // trailing\n}", map[string]any{"a": 1.0}},
+		{"block comment", `{/* a: 2, */ a: /* inline */ 1}`, map[string]any{"a": 1.0}},
+		{"comment markers in strings", `{a: "// not a comment", b: '/* nor this */'}`, map[string]any{"a": "// not a comment", "b": "/* nor this */"}},
+		{"trailing commas", `{a: [1, 2,], b: {c: 3,},}`, map[string]any{"a": []any{1.0, 2.0}, "b": map[string]any{"c": 3.0}}},
+		{"trailing comma before comment", "[1, // last\n]", []any{1.0}},
+		{"identifier keys", `{$id: 1, _x: 2, camelCase9: 3}`, map[string]any{"$id": 1.0, "_x": 2.0, "camelCase9": 3.0}},
+		{"keyword-like keys", `{true: 1, null: 2, Infinity: 3, NaN: 4}`, map[string]any{"true": 1.0, "null": 2.0, "Infinity": 3.0, "NaN": 4.0}},
+		{"single-quoted string", `{a: 'it\'s "quoted"'}`, map[string]any{"a": `it's "quoted"`}},
+		{"double-quoted string with single quote", `{a: "it's"}`, map[string]any{"a": "it's"}},
+		{"standard escapes", `{a: 'tab\tnl\nbs\\sl\/q\"'}`, map[string]any{"a": "tab\tnl\nbs\\sl/q\""}},
+		{"unicode escape", `{a: '\u00e9\u4E2D'}`, map[string]any{"a": "é中"}},
+		{"hex escape", `{a: '\x41\x7a'}`, map[string]any{"a": "Az"}},
+		{"vertical tab and null escapes", `{a: '\v\0'}`, map[string]any{"a": "\v\x00"}},
+		{"identity escapes", `{a: '\q\$'}`, map[string]any{"a": "q$"}},
+		{"line continuation", "{a: 'one \\\ntwo'}", map[string]any{"a": "one two"}},
+		{"CRLF line continuation", "{a: 'one \\\r\ntwo'}", map[string]any{"a": "one two"}},
+		{"paragraph separator continuation", "{a: 'one \\\u2029two'}", map[string]any{"a": "one two"}},
+		{"hex numbers", `{a: 0x4E20, b: 0XfF, c: -0x10}`, map[string]any{"a": 20000.0, "b": 255.0, "c": -16.0}},
+		{"large hex number", `{a: 0xFFFFFFFFFFFFFFFFFF}`, map[string]any{"a": 4722366482869645213695.0}},
+		{"leading decimal point", `{a: .5, b: -.25}`, map[string]any{"a": 0.5, "b": -0.25}},
+		{"trailing decimal point", `{a: 5., b: 5.e2}`, map[string]any{"a": 5.0, "b": 500.0}},
+		{"explicit plus sign", `{a: +1, b: +.5, c: +1e3}`, map[string]any{"a": 1.0, "b": 0.5, "c": 1000.0}},
+		{"exponents", `{a: 1e3, b: 2.5E-1, c: 1e+2}`, map[string]any{"a": 1000.0, "b": 0.25, "c": 100.0}},
+		{"infinity and NaN", `[Infinity, -Infinity, +Infinity, NaN, -NaN]`, []any{nil, nil, nil, nil, nil}},
+		{"digits in identifiers", `{model: "gpt-4o", v2: 1}`, map[string]any{"model": "gpt-4o", "v2": 1.0}},
+		{"extra whitespace", "\uFEFF{\u00a0a:\u20281\u2029}", map[string]any{"a": 1.0}},
+		{"multiline string", "{a: 'line1\nline2'}", map[string]any{"a": "line1\nline2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := json5ToJSON([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("json5ToJSON(%q) error: %v", tt.input, err)
+			}
+
+			var got any
+			if err := json.Unmarshal(out, &got); err != nil {
+				t.Fatalf("json5ToJSON(%q) = %s, not valid JSON: %v", tt.input, out, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("json5ToJSON(%q) = %s, decoded %#v, want %#v", tt.input, out, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSON5ToJSONErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unterminated string", `{a: 'open}`},
+		{"unterminated block comment", `{a: 1 /* open`},
+		{"bad unicode escape", `{a: '\u12'}`},
+		{"bad hex escape", `{a: '\xZZ'}`},
+		{"bad exponent", `{a: 1e}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if out, err := json5ToJSON([]byte(tt.input)); err == nil {
+				t.Errorf("json5ToJSON(%q) = %s, want error", tt.input, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Default number of days checked for missing daily memory files
+const defaultMemoryWindowDays = 7
+
+// dailyMemoryPattern matches daily memory file names: YYYY-MM-DD.md, or
+// YYYY-MM-DD-<slug>.md as written by the session memory hook.
+var dailyMemoryPattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})(-[A-Za-z0-9._-]+)?\.md$`)
+
+// memoryFile is a markdown file in the memory/ directory.
+type memoryFile struct {
+	name string
+	size float64
+}
+
+// memoryStats summarizes the daily memory files of a workspace.
+type memoryStats struct {
+	files         []memoryFile
+	totalBytes    float64
+	nonConforming float64
+
+	// latest is the zero time when no file has a dated name
+	latest          time.Time
+	daysSinceLatest float64
+	missingDays     float64
+}
+
+// scanMemoryDir reads the daily memory files in dir. Dates are interpreted
+// in loc, and missing days are counted over the window days ending today.
+// A missing dir yields empty stats.
+func scanMemoryDir(ctx context.Context, dir string, now time.Time, loc *time.Location, window int) (memoryStats, error) {
+	var stats memoryStats
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			stats.missingDays = float64(window)
+			return stats, nil
+		}
+		return stats, err
+	}
+
+	days := make(map[time.Time]bool)
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		file := memoryFile{name: entry.Name(), size: float64(info.Size())}
+		stats.files = append(stats.files, file)
+		stats.totalBytes += file.size
+
+		day, ok := parseMemoryDate(entry.Name(), loc)
+		if !ok {
+			stats.nonConforming++
+			continue
+		}
+		days[day] = true
+		if day.After(stats.latest) {
+			stats.latest = day
+		}
+	}
+
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	if !stats.latest.IsZero() {
+		stats.daysSinceLatest = float64(calendarDaysBetween(stats.latest, today))
+	}
+
+	for i := 0; i < window; i++ {
+		if !days[today.AddDate(0, 0, -i)] {
+			stats.missingDays++
+		}
+	}
+
+	return stats, nil
+}
+
+// parseMemoryDate returns the date of a daily memory file name at midnight
+// in loc.
+func parseMemoryDate(name string, loc *time.Location) (time.Time, bool) {
+	match := dailyMemoryPattern.FindStringSubmatch(name)
+	if match == nil {
+		return time.Time{}, false
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", match[1], loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return day, true
+}
+
+// calendarDaysBetween returns the number of calendar days from a to b, both
+// at midnight, independent of DST shifts.
+func calendarDaysBetween(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua).Hours() / 24)
+}
+
+// Long-term memory files, in the order openclaw looks for them
+var memoryDocumentNames = []string{"MEMORY.md", "memory.md"}
+
+// memoryListItemPattern matches a markdown bullet or numbered list item.
+var memoryListItemPattern = regexp.MustCompile(`^\s*([-*+]|\d+[.)])\s+\S`)
+
+// memoryDocument describes the structure of the long-term MEMORY.md file.
+type memoryDocument struct {
+	found    bool
+	sections float64
+	entries  float64
+	words    float64
+	bytes    float64
+}
+
+// readMemoryDocument parses MEMORY.md in dir. A missing file yields a
+// document with found unset.
+func readMemoryDocument(dir string) (memoryDocument, error) {
+	var doc memoryDocument
+
+	for _, name := range memoryDocumentNames {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return doc, err
+		}
+
+		doc.found = true
+		doc.bytes = float64(len(data))
+		doc.words = float64(len(strings.Fields(string(data))))
+		doc.sections, doc.entries = markdownStructure(string(data))
+		return doc, nil
+	}
+
+	return doc, nil
+}
+
+// markdownStructure counts the headings and list items of a markdown
+// document, ignoring fenced code blocks.
+func markdownStructure(text string) (sections, entries float64) {
+	inFence := false
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if level := len(trimmed) - len(strings.TrimLeft(trimmed, "#")); level > 0 && level <= 6 {
+			if rest := trimmed[level:]; rest == "" || rest[0] == ' ' || rest[0] == '\t' {
+				sections++
+				continue
+			}
+		}
+
+		if memoryListItemPattern.MatchString(line) {
+			entries++
+		}
+	}
+
+	return sections, entries
+}
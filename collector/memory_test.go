@@ -0,0 +1,247 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseMemoryDate(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+
+	tests := []struct {
+		name   string
+		file   string
+		want   string
+		wantOK bool
+	}{
+		{"plain date", "2026-03-14.md", "2026-03-14", true},
+		{"session memory slug", "2026-03-14-vendor-pitch.md", "2026-03-14", true},
+		{"slug with dots and underscores", "2026-03-14-v1.2_notes.md", "2026-03-14", true},
+		{"leap day", "2024-02-29.md", "2024-02-29", true},
+		{"invalid date", "2026-02-30.md", "", false},
+		{"invalid month", "2026-13-01.md", "", false},
+		{"not markdown", "2026-03-14.txt", "", false},
+		{"no date", "notes.md", "", false},
+		{"date not at start", "notes-2026-03-14.md", "", false},
+		{"short year", "26-03-14.md", "", false},
+		{"empty slug", "2026-03-14-.md", "", false},
+		{"slug with spaces", "2026-03-14-team sync.md", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, ok := parseMemoryDate(tt.file, loc)
+			if ok != tt.wantOK {
+				t.Fatalf("parseMemoryDate(%q) ok = %v, want %v", tt.file, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got := day.Format("2006-01-02"); got != tt.want {
+				t.Errorf("parseMemoryDate(%q) = %s, want %s", tt.file, got, tt.want)
+			}
+			if day.Location() != loc || day.Hour() != 0 || day.Minute() != 0 {
+				t.Errorf("parseMemoryDate(%q) = %v, want midnight in %v", tt.file, day, loc)
+			}
+		})
+	}
+}
+
+func TestCalendarDaysBetween(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		a, b time.Time
+		want int
+	}{
+		{"same day", time.Date(2026, 3, 14, 0, 0, 0, 0, berlin), time.Date(2026, 3, 14, 0, 0, 0, 0, berlin), 0},
+		{"next day", time.Date(2026, 3, 14, 0, 0, 0, 0, berlin), time.Date(2026, 3, 15, 0, 0, 0, 0, berlin), 1},
+		{"across spring DST change", time.Date(2026, 3, 28, 0, 0, 0, 0, berlin), time.Date(2026, 3, 30, 0, 0, 0, 0, berlin), 2},
+		{"across autumn DST change", time.Date(2026, 10, 24, 0, 0, 0, 0, berlin), time.Date(2026, 10, 26, 0, 0, 0, 0, berlin), 2},
+		{"across year end", time.Date(2025, 12, 31, 0, 0, 0, 0, berlin), time.Date(2026, 1, 1, 0, 0, 0, 0, berlin), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calendarDaysBetween(tt.a, tt.b); got != tt.want {
+				t.Errorf("calendarDaysBetween(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanMemoryDir(t *testing.T) {
+	now := time.Date(2026, 3, 14, 22, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		files             map[string]string
+		missingDir        bool
+		window            int
+		wantFiles         int
+		wantBytes         float64
+		wantNonConforming float64
+		wantLatest        string
+		wantDaysSince     float64
+		wantMissing       float64
+	}{
+		{
+			name:        "missing directory",
+			missingDir:  true,
+			window:      7,
+			wantMissing: 7,
+		},
+		{
+			name:        "empty directory",
+			window:      7,
+			wantMissing: 7,
+		},
+		{
+			name: "daily files with gaps",
+			files: map[string]string{
+				"2026-03-14.md":          "today",
+				"2026-03-12-standup.md":  "two days ago",
+				"2026-03-12.md":          "same day",
+				"2026-03-01.md":          "outside window",
+				"ideas.md":               "no date",
+				"2026-03-13.txt":         "not markdown",
+				"2026-03-11-bad name.md": "invalid slug",
+			},
+			window:            7,
+			wantFiles:         6,
+			wantBytes:         float64(len("today") + len("two days ago") + len("same day") + len("outside window") + len("no date") + len("invalid slug")),
+			wantNonConforming: 2,
+			wantLatest:        "2026-03-14",
+			wantDaysSince:     0,
+			wantMissing:       5,
+		},
+		{
+			name:          "journaling stopped",
+			files:         map[string]string{"2026-03-10.md": "x"},
+			window:        3,
+			wantFiles:     1,
+			wantBytes:     1,
+			wantLatest:    "2026-03-10",
+			wantDaysSince: 4,
+			wantMissing:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "memory")
+			if !tt.missingDir {
+				if err := os.Mkdir(dir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Mkdir(filepath.Join(dir, "2026-03-13.md"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			stats, err := scanMemoryDir(context.Background(), dir, now, time.UTC, tt.window)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(stats.files) != tt.wantFiles {
+				t.Errorf("files = %d, want %d", len(stats.files), tt.wantFiles)
+			}
+			if stats.totalBytes != tt.wantBytes {
+				t.Errorf("totalBytes = %v, want %v", stats.totalBytes, tt.wantBytes)
+			}
+			if stats.nonConforming != tt.wantNonConforming {
+				t.Errorf("nonConforming = %v, want %v", stats.nonConforming, tt.wantNonConforming)
+			}
+			if stats.missingDays != tt.wantMissing {
+				t.Errorf("missingDays = %v, want %v", stats.missingDays, tt.wantMissing)
+			}
+			if stats.daysSinceLatest != tt.wantDaysSince {
+				t.Errorf("daysSinceLatest = %v, want %v", stats.daysSinceLatest, tt.wantDaysSince)
+			}
+			latest := ""
+			if !stats.latest.IsZero() {
+				latest = stats.latest.Format("2006-01-02")
+			}
+			if latest != tt.wantLatest {
+				t.Errorf("latest = %q, want %q", latest, tt.wantLatest)
+			}
+		})
+	}
+}
+
+func TestScanMemoryDirCanceled(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "2026-03-14.md"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := scanMemoryDir(ctx, dir, time.Now(), time.UTC, 7); err == nil {
+		t.Error("scanMemoryDir with a canceled context returned no error")
+	}
+}
+
+func TestMarkdownStructure(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantSections float64
+		wantEntries  float64
+	}{
+		{"empty", "", 0, 0},
+		{"headings", "# Memory\n## People\n###### Deep\n####### Too deep\n#hashtag", 3, 0},
+		{"bare heading", "##\n", 1, 0},
+		{"list items", "- one\n* two\n+ three\n1. four\n2) five\n-not an item\n- ", 0, 5},
+		{"nested items", "- parent\n  - child\n    1. grandchild", 0, 3},
+		{"fenced code ignored", "# Notes\n```\n# not a heading\n- not an entry\n```\n- entry\n~~~md\n## hidden\n~~~", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sections, entries := markdownStructure(tt.text)
+			if sections != tt.wantSections || entries != tt.wantEntries {
+				t.Errorf("markdownStructure(%q) = %v, %v, want %v, %v", tt.text, sections, entries, tt.wantSections, tt.wantEntries)
+			}
+		})
+	}
+}
+
+func TestReadMemoryDocument(t *testing.T) {
+	dir := t.TempDir()
+
+	doc, err := readMemoryDocument(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.found {
+		t.Error("readMemoryDocument found a document in an empty directory")
+	}
+
+	content := "# People\n- Alice prefers email\n- Bob is on call\n"
+	if err := os.WriteFile(filepath.Join(dir, "memory.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err = readMemoryDocument(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !doc.found || doc.bytes != float64(len(content)) || doc.words != 11 || doc.sections != 1 || doc.entries != 2 {
+		t.Errorf("readMemoryDocument = %+v, want found with %d bytes, 11 words, 1 section and 2 entries", doc, len(content))
+	}
+}
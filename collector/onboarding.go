@@ -0,0 +1,168 @@
+package collector
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Directory of the workspace templates in the openclaw package
+var workspaceTemplatesDir = filepath.Join("docs", "reference", "templates")
+
+// onboardingFiles are the workspace files the BOOTSTRAP.md ritual asks the
+// agent to fill in.
+var onboardingFiles = []string{"IDENTITY.md", "USER.md"}
+
+// Onboarding statuses of a workspace
+const (
+	onboardingNotStarted = "not_started"
+	onboardingInProgress = "in_progress"
+	onboardingComplete   = "complete"
+)
+
+// templateFile reports whether a workspace file still matches the template
+// openclaw seeded it from.
+type templateFile struct {
+	name      string
+	unchanged bool
+}
+
+// onboardingState is the first-run onboarding progress of a workspace.
+type onboardingState struct {
+	// status is empty when the workspace does not exist
+	status        string
+	templateFiles []templateFile
+}
+
+// loadWorkspaceTemplates reads the workspace templates shipped in the
+// openclaw package at installDir, keyed by file name. It returns nil when the
+// package has no templates directory.
+func loadWorkspaceTemplates(installDir string) (map[string]string, error) {
+	dir := filepath.Join(installDir, workspaceTemplatesDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	templates := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".md") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		templates[entry.Name()] = normalizeTemplate(data)
+	}
+
+	return templates, nil
+}
+
+// readOnboardingState derives the onboarding status of the workspace in dir.
+// BOOTSTRAP.md is deleted once onboarding finishes, so while it exists the
+// status is not_started, or in_progress once an onboarding file has been
+// customised. Without it, onboarding files still matching their templates
+// leave the status in_progress. templates is nil when the openclaw install
+// was not found, in which case only BOOTSTRAP.md is considered.
+func readOnboardingState(dir string, templates map[string]string) (onboardingState, error) {
+	var state onboardingState
+
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, err
+	}
+
+	bootstrap, err := fileExists(filepath.Join(dir, "BOOTSTRAP.md"))
+	if err != nil {
+		return state, err
+	}
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		if name != "BOOTSTRAP.md" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	unchanged := make(map[string]bool)
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return state, err
+		}
+
+		same := normalizeTemplate(data) == templates[name]
+		unchanged[name] = same
+		state.templateFiles = append(state.templateFiles, templateFile{name: name, unchanged: same})
+	}
+
+	customised, pristine := false, false
+	for _, name := range onboardingFiles {
+		same, ok := unchanged[name]
+		switch {
+		case !ok:
+			// A missing file was never seeded, which says nothing either way
+		case same:
+			pristine = true
+		default:
+			customised = true
+		}
+	}
+
+	switch {
+	case bootstrap && customised:
+		state.status = onboardingInProgress
+	case bootstrap:
+		state.status = onboardingNotStarted
+	case pristine:
+		state.status = onboardingInProgress
+	default:
+		state.status = onboardingComplete
+	}
+
+	return state, nil
+}
+
+// normalizeTemplate drops the front matter openclaw strips when seeding a
+// workspace, line ending differences and surrounding whitespace.
+func normalizeTemplate(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	if frontmatter, ok := extractFrontmatter(data); ok {
+		// Skip the opening delimiter, the front matter and the closing line
+		rest := data[bytes.IndexByte(data, '\n')+1+len(frontmatter):]
+		if end := bytes.IndexByte(rest, '\n'); end >= 0 {
+			data = rest[end+1:]
+		} else {
+			data = nil
+		}
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// fileExists reports whether a file exists at path.
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
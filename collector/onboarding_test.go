@@ -0,0 +1,187 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"plain", "# USER.md\n\n- Name:\n", "# USER.md\n\n- Name:"},
+		{"front matter", "---\nsummary: \"User profile\"\nread_when:\n  - Bootstrapping\n---\n\n# USER.md\n", "# USER.md"},
+		{"CRLF front matter", "---\r\nsummary: x\r\n---\r\n# USER.md\r\n- Name:\r\n", "# USER.md\n- Name:"},
+		{"byte order mark", "\ufeff---\nsummary: x\n---\n# USER.md", "# USER.md"},
+		{"front matter only", "---\nsummary: x\n---", ""},
+		{"horizontal rule is not front matter", "# USER.md\n---\nnotes", "# USER.md\n---\nnotes"},
+		{"surrounding whitespace", "\n\n  # USER.md  \n\n", "# USER.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeTemplate([]byte(tt.data)); got != tt.want {
+				t.Errorf("normalizeTemplate(%q) = %q, want %q", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadOnboardingState(t *testing.T) {
+	templates := map[string]string{
+		"BOOTSTRAP.md": "# BOOTSTRAP.md",
+		"IDENTITY.md":  "# IDENTITY.md\n\n- Name:",
+		"USER.md":      "# USER.md\n\n- Name:",
+		"SOUL.md":      "# SOUL.md",
+	}
+	const (
+		pristineIdentity = "---\nsummary: identity\n---\n# IDENTITY.md\n\n- Name:\n"
+		pristineUser     = "# USER.md\r\n\r\n- Name:\r\n"
+		filledIdentity   = "# IDENTITY.md\n\n- Name: Molty\n"
+		filledUser       = "# USER.md\n\n- Name: Sam\n"
+	)
+
+	tests := []struct {
+		name          string
+		files         map[string]string
+		noWorkspace   bool
+		noTemplates   bool
+		wantStatus    string
+		wantTemplates []templateFile
+	}{
+		{
+			name:        "missing workspace",
+			noWorkspace: true,
+			wantStatus:  "",
+		},
+		{
+			name:       "fresh workspace",
+			files:      map[string]string{"BOOTSTRAP.md": "# BOOTSTRAP.md", "IDENTITY.md": pristineIdentity, "USER.md": pristineUser},
+			wantStatus: onboardingNotStarted,
+			wantTemplates: []templateFile{
+				{name: "IDENTITY.md", unchanged: true},
+				{name: "USER.md", unchanged: true},
+			},
+		},
+		{
+			name:       "onboarding underway",
+			files:      map[string]string{"BOOTSTRAP.md": "# BOOTSTRAP.md", "IDENTITY.md": filledIdentity, "USER.md": pristineUser},
+			wantStatus: onboardingInProgress,
+			wantTemplates: []templateFile{
+				{name: "IDENTITY.md", unchanged: false},
+				{name: "USER.md", unchanged: true},
+			},
+		},
+		{
+			name:       "bootstrap deleted but files untouched",
+			files:      map[string]string{"IDENTITY.md": pristineIdentity, "USER.md": filledUser},
+			wantStatus: onboardingInProgress,
+			wantTemplates: []templateFile{
+				{name: "IDENTITY.md", unchanged: true},
+				{name: "USER.md", unchanged: false},
+			},
+		},
+		{
+			name:       "complete",
+			files:      map[string]string{"IDENTITY.md": filledIdentity, "USER.md": filledUser, "SOUL.md": "# SOUL.md\n"},
+			wantStatus: onboardingComplete,
+			wantTemplates: []templateFile{
+				{name: "IDENTITY.md", unchanged: false},
+				{name: "SOUL.md", unchanged: true},
+				{name: "USER.md", unchanged: false},
+			},
+		},
+		{
+			name:       "onboarding files missing",
+			files:      map[string]string{"SOUL.md": "# SOUL.md"},
+			wantStatus: onboardingComplete,
+			wantTemplates: []templateFile{
+				{name: "SOUL.md", unchanged: true},
+			},
+		},
+		{
+			name:        "no templates with bootstrap",
+			files:       map[string]string{"BOOTSTRAP.md": "# BOOTSTRAP.md", "IDENTITY.md": pristineIdentity},
+			noTemplates: true,
+			wantStatus:  onboardingNotStarted,
+		},
+		{
+			name:        "no templates without bootstrap",
+			files:       map[string]string{"IDENTITY.md": pristineIdentity},
+			noTemplates: true,
+			wantStatus:  onboardingComplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "workspace")
+			if !tt.noWorkspace {
+				if err := os.Mkdir(dir, 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			tmpl := templates
+			if tt.noTemplates {
+				tmpl = nil
+			}
+
+			state, err := readOnboardingState(dir, tmpl)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state.status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", state.status, tt.wantStatus)
+			}
+			if !reflect.DeepEqual(state.templateFiles, tt.wantTemplates) {
+				t.Errorf("templateFiles = %+v, want %+v", state.templateFiles, tt.wantTemplates)
+			}
+		})
+	}
+}
+
+func TestLoadWorkspaceTemplates(t *testing.T) {
+	installDir := t.TempDir()
+
+	templates, err := loadWorkspaceTemplates(installDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if templates != nil {
+		t.Errorf("templates = %v for a package without templates, want nil", templates)
+	}
+
+	dir := filepath.Join(installDir, workspaceTemplatesDir)
+	if err := os.MkdirAll(filepath.Join(dir, "nested.md"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"USER.md":    "---\nsummary: x\n---\n# USER.md\n",
+		"AGENTS.MD":  "# AGENTS.md",
+		"README.txt": "not a template",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates, err = loadWorkspaceTemplates(installDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"USER.md": "# USER.md", "AGENTS.MD": "# AGENTS.md"}
+	if !reflect.DeepEqual(templates, want) {
+		t.Errorf("templates = %v, want %v", templates, want)
+	}
+}
@@ -0,0 +1,230 @@
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Gateway configuration file in the openclaw home directory
+const openclawConfigFile = "openclaw.json"
+
+// Default heartbeat interval when agents.defaults.heartbeat.every is unset
+const defaultHeartbeatEvery = 30 * time.Minute
+
+// openclawConfig holds the parts of openclaw.json the exporter reports on.
+type openclawConfig struct {
+	Meta struct {
+		LastTouchedVersion string `json:"lastTouchedVersion"`
+	} `json:"meta"`
+	Agents struct {
+		Defaults struct {
+			Workspace         string      `json:"workspace"`
+			Model             configModel `json:"model"`
+			BootstrapMaxChars int         `json:"bootstrapMaxChars"`
+			Heartbeat         struct {
+				Every  string `json:"every"`
+				Prompt string `json:"prompt"`
+			} `json:"heartbeat"`
+			Sandbox struct {
+				Mode string `json:"mode"`
+			} `json:"sandbox"`
+		} `json:"defaults"`
+		List []configAgent `json:"list"`
+	} `json:"agents"`
+	Channels map[string]json.RawMessage `json:"channels"`
+	Skills   struct {
+		Entries map[string]configSkillEntry `json:"entries"`
+	} `json:"skills"`
+}
+
+// configSkillEntry is an entry of skills.entries, keyed by skill name.
+type configSkillEntry struct {
+	Enabled *bool             `json:"enabled"`
+	APIKey  json.RawMessage   `json:"apiKey"`
+	Env     map[string]string `json:"env"`
+}
+
+// configAgent is an entry of agents.list.
+type configAgent struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Default   bool        `json:"default"`
+	Workspace string      `json:"workspace"`
+	Model     configModel `json:"model"`
+}
+
+// configModel accepts either a model string or a {primary, fallbacks} object.
+type configModel struct {
+	Primary   string   `json:"primary"`
+	Fallbacks []string `json:"fallbacks"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (m *configModel) UnmarshalJSON(data []byte) error {
+	var primary string
+	if err := json.Unmarshal(data, &primary); err == nil {
+		m.Primary = primary
+		return nil
+	}
+
+	type plain configModel
+	var model plain
+	if err := json.Unmarshal(data, &model); err != nil {
+		return err
+	}
+	*m = configModel(model)
+
+	return nil
+}
+
+// loadedConfig is a parsed openclaw.json together with file metadata.
+type loadedConfig struct {
+	path    string
+	config  openclawConfig
+	raw     map[string]any
+	hash    string
+	modTime time.Time
+}
+
+// resolveOpenclawConfigPath returns the gateway config path, honouring the
+// OPENCLAW_CONFIG_PATH override used by openclaw itself.
+func resolveOpenclawConfigPath(openclawHome string) string {
+	if path := os.Getenv("OPENCLAW_CONFIG_PATH"); path != "" {
+		return path
+	}
+
+	return filepath.Join(openclawHome, openclawConfigFile)
+}
+
+// loadOpenclawConfig reads and parses openclaw.json, tolerating JSON5 syntax.
+func loadOpenclawConfig(openclawHome string) (*loadedConfig, error) {
+	path := resolveOpenclawConfigPath(openclawHome)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, err := json5ToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	loaded := &loadedConfig{
+		path:    path,
+		modTime: info.ModTime(),
+	}
+
+	// Fields with unexpected types are skipped rather than failing the whole file
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal(normalized, &loaded.config); err != nil && !errors.As(err, &typeErr) {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := json.Unmarshal(normalized, &loaded.raw); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	loaded.config.Agents.List = uniqueConfigAgents(path, loaded.config.Agents.List)
+
+	sum := sha256.Sum256(data)
+	loaded.hash = hex.EncodeToString(sum[:])
+
+	return loaded, nil
+}
+
+// uniqueConfigAgents drops agents.list entries whose ID repeats an earlier
+// entry, which would otherwise export duplicate series.
+func uniqueConfigAgents(path string, agents []configAgent) []configAgent {
+	seen := make(map[string]bool)
+	unique := make([]configAgent, 0, len(agents))
+
+	for _, agent := range agents {
+		if agent.ID != "" && seen[agent.ID] {
+			log.Printf("Ignoring duplicate agent id %q in %s", agent.ID, path)
+			continue
+		}
+		seen[agent.ID] = true
+		unique = append(unique, agent)
+	}
+
+	return unique
+}
+
+// lookup returns the value at a dotted config path such as "browser.enabled".
+func (c *loadedConfig) lookup(path string) (any, bool) {
+	var value any = c.raw
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// heartbeatInterval returns the configured default heartbeat interval.
+func (c *openclawConfig) heartbeatInterval() (time.Duration, error) {
+	if c.Agents.Defaults.Heartbeat.Every == "" {
+		return defaultHeartbeatEvery, nil
+	}
+
+	return parseConfigDuration(c.Agents.Defaults.Heartbeat.Every)
+}
+
+// enabledChannels reports each configured channel and whether it is enabled.
+// Channels are enabled unless they explicitly set "enabled": false.
+func (c *openclawConfig) enabledChannels() map[string]bool {
+	channels := make(map[string]bool)
+
+	for name, raw := range c.Channels {
+		var channel struct {
+			Enabled *bool `json:"enabled"`
+		}
+		if err := json.Unmarshal(raw, &channel); err != nil {
+			// Not an object (e.g. shared channel defaults); skip
+			continue
+		}
+		channels[name] = channel.Enabled == nil || *channel.Enabled
+	}
+
+	return channels
+}
+
+// parseConfigDuration parses openclaw duration strings such as "30m", "1h"
+// or "2d". A bare number is interpreted as minutes.
+func parseConfigDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return d, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.ParseFloat(days, 64); err == nil {
+			return time.Duration(n * float64(24*time.Hour)), nil
+		}
+	}
+
+	if minutes, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(minutes * float64(time.Minute)), nil
+	}
+
+	return 0, fmt.Errorf("invalid duration %q", value)
+}
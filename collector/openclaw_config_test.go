@@ -0,0 +1,48 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadOpenclawConfigDuplicateAgents(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("OPENCLAW_CONFIG_PATH", "")
+	config := `{
+  agents: {
+    list: [
+      { id: "main", name: "First", default: true },
+      { id: "work", model: "openai/gpt-5" },
+      { id: "main", name: "Second" },
+    ],
+  },
+}`
+	if err := os.WriteFile(filepath.Join(home, openclawConfigFile), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadOpenclawConfig(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list := loaded.config.Agents.List
+	if len(list) != 2 {
+		t.Fatalf("agents.list has %d entries, want 2: %+v", len(list), list)
+	}
+	if list[0].ID != "main" || list[0].Name != "First" {
+		t.Errorf("first agent = %+v, want the first main entry", list[0])
+	}
+	if list[1].ID != "work" {
+		t.Errorf("second agent = %q, want work", list[1].ID)
+	}
+
+	agents, err := discoverAgents(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 2 {
+		t.Errorf("discoverAgents returned %d agents, want 2: %+v", len(agents), agents)
+	}
+}
@@ -0,0 +1,157 @@
+package collector
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+)
+
+// Bytes scanned at the start of each file; the rest of larger files is
+// skipped
+const secretScanMaxBytes = 1 << 20
+
+// SecretPattern is a named regular expression matching a suspected secret.
+type SecretPattern struct {
+	Name  string `yaml:"name"`
+	Regex string `yaml:"regex"`
+}
+
+// SecretScanConfig enables scanning workspace files for leaked secrets.
+type SecretScanConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Patterns are checked after the built-in patterns
+	Patterns []SecretPattern `yaml:"patterns"`
+}
+
+// defaultSecretPatterns match common API keys, tokens and private keys.
+// More specific patterns come first: a match is only counted for the first
+// pattern that finds it.
+var defaultSecretPatterns = []SecretPattern{
+	{Name: "private_key", Regex: `-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----`},
+	{Name: "anthropic_api_key", Regex: `\bsk-ant-[A-Za-z0-9_-]{20,}`},
+	{Name: "openai_api_key", Regex: `\bsk-(?:proj-|svcacct-|admin-)?[A-Za-z0-9_-]{20,}`},
+	{Name: "github_token", Regex: `\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`},
+	{Name: "aws_access_key_id", Regex: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+	{Name: "google_api_key", Regex: `\bAIza[0-9A-Za-z_-]{35}`},
+	{Name: "slack_token", Regex: `\bxox[abposr]-[A-Za-z0-9-]{10,}`},
+	{Name: "stripe_key", Regex: `\b[rs]k_(?:live|test)_[0-9A-Za-z]{16,}`},
+	{Name: "telegram_bot_token", Regex: `\b[0-9]{8,10}:AA[A-Za-z0-9_-]{33}\b`},
+	{Name: "bearer_token", Regex: `(?i)\bbearer\s+[A-Za-z0-9._~+/-]{20,}=*`},
+	{Name: "jwt", Regex: `\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`},
+}
+
+// fileSecrets is the number of suspected secrets in a workspace file by
+// pattern name. name is relative to the workspace, e.g. "memory/2026-01-02.md".
+type fileSecrets struct {
+	name   string
+	counts map[string]float64
+}
+
+// compiledSecretPattern is a SecretPattern ready for matching.
+type compiledSecretPattern struct {
+	name  string
+	regex *regexp.Regexp
+}
+
+// SecretScanner counts suspected secrets in workspace files. Only counts per
+// pattern are kept; matched text is never stored. It is safe for concurrent
+// use.
+type SecretScanner struct {
+	patterns []compiledSecretPattern
+
+	cache *fileCache[string, map[string]float64]
+}
+
+// NewSecretScanner creates a SecretScanner from cfg, compiling the built-in
+// and custom patterns. It returns nil when scanning is disabled.
+func NewSecretScanner(cfg SecretScanConfig) (*SecretScanner, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	s := &SecretScanner{cache: newFileCache[string, map[string]float64]()}
+	names := make(map[string]bool)
+
+	patterns := append(append([]SecretPattern{}, defaultSecretPatterns...), cfg.Patterns...)
+	for _, pattern := range patterns {
+		if pattern.Name == "" {
+			return nil, fmt.Errorf("secret pattern %q without name", pattern.Regex)
+		}
+		if names[pattern.Name] {
+			return nil, fmt.Errorf("duplicate secret pattern name %q", pattern.Name)
+		}
+		names[pattern.Name] = true
+
+		regex, err := regexp.Compile(pattern.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("secret pattern %q: %w", pattern.Name, err)
+		}
+		if regex.MatchString("") {
+			return nil, fmt.Errorf("secret pattern %q matches empty text", pattern.Name)
+		}
+
+		s.patterns = append(s.patterns, compiledSecretPattern{name: pattern.Name, regex: regex})
+	}
+
+	return s, nil
+}
+
+// scanFile returns the number of suspected secrets in the file at path by
+// pattern name, omitting patterns without matches. Only the first
+// secretScanMaxBytes of the file are scanned. Counts are cached until the
+// file's size or modification time changes.
+func (s *SecretScanner) scanFile(path string, info os.FileInfo) (map[string]float64, error) {
+	if counts, ok := s.cache.get(path, info); ok {
+		return counts, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, secretScanMaxBytes))
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > secretScanMaxBytes {
+		log.Printf("Scanning only the first %d of %d bytes of %s for secrets", secretScanMaxBytes, info.Size(), path)
+	}
+
+	counts := s.scan(data)
+
+	s.cache.put(path, info, counts)
+
+	return counts, nil
+}
+
+// prune drops the cached counts of files not seen since the previous prune.
+func (s *SecretScanner) prune() {
+	s.cache.prune()
+}
+
+// scan counts the suspected secrets in data. Matched text is blanked out so
+// that later, more general patterns do not count the same secret again.
+func (s *SecretScanner) scan(data []byte) map[string]float64 {
+	text := append([]byte(nil), data...)
+	counts := make(map[string]float64)
+
+	for _, pattern := range s.patterns {
+		matches := pattern.regex.FindAllIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		counts[pattern.name] += float64(len(matches))
+
+		for _, match := range matches {
+			for i := match[0]; i < match[1]; i++ {
+				text[i] = ' '
+			}
+		}
+	}
+
+	return counts
+}
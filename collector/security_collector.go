@@ -0,0 +1,256 @@
+package collector
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Path classes audited by the SecurityCollector
+var securityPathClasses = []string{"config", "credentials", "auth", "sessions"}
+
+// Permissions change rarely, so the audit runs less often than the
+// workspace scan
+const securityAuditInterval = 5 * time.Minute
+
+// securityAudit counts the files of one path class with loose permissions.
+type securityAudit struct {
+	files           float64
+	groupReadable   float64
+	worldReadable   float64
+	unexpectedOwner float64
+}
+
+// SecurityCollector audits the file permissions of the openclaw home
+// directory, which holds API keys, OAuth tokens and session transcripts.
+// The audit runs in the background and scrapes report its last result.
+type SecurityCollector struct {
+	openclawHome string
+	mu           sync.RWMutex
+
+	filesTotal      *prometheus.Desc
+	groupReadable   *prometheus.Desc
+	worldReadable   *prometheus.Desc
+	unexpectedOwner *prometheus.Desc
+	scrapeSuccess   *prometheus.Desc
+
+	auditInterval time.Duration
+	auditTimeout  time.Duration
+	// audits is nil until an audit succeeds and after an audit fails
+	audits map[string]*securityAudit
+}
+
+// NewSecurityCollector creates a new SecurityCollector.
+func NewSecurityCollector(openclawHome string) *SecurityCollector {
+	if openclawHome == "" {
+		openclawHome = os.Getenv("HOME") + defaultOpenclawHome
+	}
+
+	c := &SecurityCollector{
+		openclawHome:  openclawHome,
+		auditInterval: securityAuditInterval,
+		auditTimeout:  defaultScanTimeout,
+		filesTotal: prometheus.NewDesc(
+			"openclaw_security_files_total",
+			"Number of audited files in the openclaw home by path class",
+			[]string{"class"}, nil,
+		),
+		groupReadable: prometheus.NewDesc(
+			"openclaw_security_group_readable_files",
+			"Number of audited files readable by their group",
+			[]string{"class"}, nil,
+		),
+		worldReadable: prometheus.NewDesc(
+			"openclaw_security_world_readable_files",
+			"Number of audited files readable by all users",
+			[]string{"class"}, nil,
+		),
+		unexpectedOwner: prometheus.NewDesc(
+			"openclaw_security_unexpected_owner_files",
+			"Number of audited files not owned by the owner of the openclaw home directory",
+			[]string{"class"}, nil,
+		),
+		scrapeSuccess: prometheus.NewDesc(
+			"openclaw_security_scrape_success",
+			"Whether the last openclaw home permission audit was successful",
+			nil, nil,
+		),
+	}
+
+	// Windows ACLs are not reflected in file mode bits
+	if runtime.GOOS != "windows" {
+		go c.startBackgroundAudit()
+	}
+
+	return c
+}
+
+func (c *SecurityCollector) startBackgroundAudit() {
+	c.refreshAudit()
+
+	ticker := time.NewTicker(c.auditInterval)
+	for range ticker.C {
+		c.refreshAudit()
+	}
+}
+
+func (c *SecurityCollector) refreshAudit() {
+	ctx, cancel := context.WithTimeout(context.Background(), c.auditTimeout)
+	defer cancel()
+
+	audits, err := auditOpenclawHome(ctx, c.openclawHome)
+	if err != nil {
+		log.Printf("Error auditing openclaw home permissions: %v", err)
+	}
+
+	c.mu.Lock()
+	c.audits = audits
+	c.mu.Unlock()
+}
+
+// Describe implements prometheus.Collector.
+func (c *SecurityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.filesTotal
+	ch <- c.groupReadable
+	ch <- c.worldReadable
+	ch <- c.unexpectedOwner
+	ch <- c.scrapeSuccess
+}
+
+// Collect implements prometheus.Collector.
+func (c *SecurityCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	audits := c.audits
+	c.mu.RUnlock()
+
+	// No audit yet, a failed audit, or Windows where none is run
+	if audits == nil {
+		ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 0)
+		return
+	}
+
+	for _, class := range securityPathClasses {
+		audit := audits[class]
+		ch <- prometheus.MustNewConstMetric(c.filesTotal, prometheus.GaugeValue, audit.files, class)
+		ch <- prometheus.MustNewConstMetric(c.groupReadable, prometheus.GaugeValue, audit.groupReadable, class)
+		ch <- prometheus.MustNewConstMetric(c.worldReadable, prometheus.GaugeValue, audit.worldReadable, class)
+		ch <- prometheus.MustNewConstMetric(c.unexpectedOwner, prometheus.GaugeValue, audit.unexpectedOwner, class)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 1)
+}
+
+// auditOpenclawHome checks the files of each path class in home:
+// openclaw.json and its backups plus .env (config), credentials/
+// (credentials), agents/*/agent/ (auth) and agents/*/sessions/ (sessions).
+// Files are expected to be owned by the owner of home. Symlinks are not
+// followed. The walk stops with ctx's error once ctx is done.
+func auditOpenclawHome(ctx context.Context, home string) (map[string]*securityAudit, error) {
+	audits := make(map[string]*securityAudit)
+	for _, class := range securityPathClasses {
+		audits[class] = &securityAudit{}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(home)
+	if err != nil {
+		// Nothing to audit before openclaw first runs
+		if os.IsNotExist(err) {
+			return audits, nil
+		}
+		return nil, err
+	}
+	owner, hasOwner := fileOwner(info)
+
+	audit := func(class string, info os.FileInfo) {
+		if !info.Mode().IsRegular() {
+			return
+		}
+
+		a := audits[class]
+		a.files++
+		if info.Mode().Perm()&0o040 != 0 {
+			a.groupReadable++
+		}
+		if info.Mode().Perm()&0o004 != 0 {
+			a.worldReadable++
+		}
+		if uid, ok := fileOwner(info); hasOwner && ok && uid != owner {
+			a.unexpectedOwner++
+		}
+	}
+
+	configFiles, err := filepath.Glob(filepath.Join(home, "openclaw.json*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range append(configFiles, filepath.Join(home, ".env")) {
+		info, err := os.Lstat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		audit("config", info)
+	}
+
+	authDirs, err := filepath.Glob(filepath.Join(home, "agents", "*", "agent"))
+	if err != nil {
+		return nil, err
+	}
+	sessionDirs, err := filepath.Glob(filepath.Join(home, "agents", "*", "sessions"))
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := map[string][]string{
+		"credentials": {filepath.Join(home, "credentials")},
+		"auth":        authDirs,
+		"sessions":    sessionDirs,
+	}
+	for class, roots := range dirs {
+		for _, root := range roots {
+			err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return ctxErr
+				}
+				if err != nil {
+					// Files may disappear while sessions are rotated
+					if os.IsNotExist(err) {
+						return nil
+					}
+					return err
+				}
+				if entry.IsDir() {
+					return nil
+				}
+
+				info, err := entry.Info()
+				if err != nil {
+					if os.IsNotExist(err) {
+						return nil
+					}
+					return err
+				}
+				audit(class, info)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return audits, nil
+}
@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestAuditOpenclawHome(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes do not reflect ACLs on Windows")
+	}
+
+	home := t.TempDir()
+	files := map[string]os.FileMode{
+		"openclaw.json":                        0o600,
+		"openclaw.json.bak":                    0o644,
+		".env":                                 0o640,
+		"credentials/oauth.json":               0o600,
+		"agents/main/agent/auth-profiles.json": 0o604,
+		"agents/main/sessions/sessions.json":   0o600,
+		"agents/main/sessions/s1.jsonl":        0o644,
+		"agents/work/sessions/nested/s2.jsonl": 0o600,
+		"agents/main/unaudited/notes.md":       0o644,
+	}
+	for name, mode := range files {
+		path := filepath.Join(home, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, mode); err != nil {
+			t.Fatal(err)
+		}
+		// Apply the mode regardless of the umask
+		if err := os.Chmod(path, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	audits, err := auditOpenclawHome(context.Background(), home)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]securityAudit{
+		"config":      {files: 3, groupReadable: 2, worldReadable: 1},
+		"credentials": {files: 1},
+		"auth":        {files: 1, worldReadable: 1},
+		"sessions":    {files: 3, groupReadable: 1, worldReadable: 1},
+	}
+	for class, w := range want {
+		if got := *audits[class]; got != w {
+			t.Errorf("audit of %s = %+v, want %+v", class, got, w)
+		}
+	}
+}
+
+func TestAuditOpenclawHomeMissing(t *testing.T) {
+	audits, err := auditOpenclawHome(context.Background(), filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, class := range securityPathClasses {
+		if audits[class].files != 0 {
+			t.Errorf("audit of %s counted %v files in a missing home", class, audits[class].files)
+		}
+	}
+}
+
+func TestAuditOpenclawHomeCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := auditOpenclawHome(ctx, t.TempDir()); err == nil {
+		t.Error("auditOpenclawHome with a canceled context returned no error")
+	}
+}
@@ -3,10 +3,13 @@ package collector
 import (
 	"bufio"
 	"encoding/json"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -17,18 +20,20 @@ const defaultOpenclawHome = "/.openclaw"
 // SessionCollector collects runtime session metrics from openclaw.
 type SessionCollector struct {
 	openclawHome string
+	location     *time.Location
+	skills       SkillInventory
 
 	// Session info
-	sessionActive     *prometheus.Desc
-	sessionMessages   *prometheus.Desc
-	sessionUpdated    *prometheus.Desc
+	sessionActive   *prometheus.Desc
+	sessionMessages *prometheus.Desc
+	sessionUpdated  *prometheus.Desc
 
 	// Token usage
-	sessionTokensInput     *prometheus.Desc
-	sessionTokensOutput    *prometheus.Desc
-	sessionTokensCacheRead *prometheus.Desc
+	sessionTokensInput      *prometheus.Desc
+	sessionTokensOutput     *prometheus.Desc
+	sessionTokensCacheRead  *prometheus.Desc
 	sessionTokensCacheWrite *prometheus.Desc
-	sessionTokensTotal     *prometheus.Desc
+	sessionTokensTotal      *prometheus.Desc
 
 	// Cost
 	sessionCostTotal *prometheus.Desc
@@ -48,18 +53,42 @@ type SessionCollector struct {
 	// Thinking level
 	thinkingLevel *prometheus.Desc
 
+	// Activity profile
+	activityMessagesByHour    *prometheus.Desc
+	activityTokensByHour      *prometheus.Desc
+	activityMessagesByWeekday *prometheus.Desc
+	activityTokensByWeekday   *prometheus.Desc
+
+	// Heartbeat runs
+	heartbeatRuns         *prometheus.Desc
+	heartbeatCost         *prometheus.Desc
+	heartbeatOutcomes     *prometheus.Desc
+	heartbeatLastRun      *prometheus.Desc
+	heartbeatSinceLastRun *prometheus.Desc
+
+	// Skill usage
+	skillUsage    *prometheus.Desc
+	skillLastUsed *prometheus.Desc
+
 	// Scrape success
 	scrapeSuccess *prometheus.Desc
 }
 
-// NewSessionCollector creates a new SessionCollector.
-func NewSessionCollector(openclawHome string) *SessionCollector {
+// NewSessionCollector creates a new SessionCollector. Activity metrics are
+// bucketed by hour and weekday in location, or in local time if nil. Skill
+// usage is reported for every skill in the inventory, if one is given.
+func NewSessionCollector(openclawHome string, location *time.Location, skills SkillInventory) *SessionCollector {
 	if openclawHome == "" {
 		openclawHome = os.Getenv("HOME") + defaultOpenclawHome
 	}
+	if location == nil {
+		location = time.Local
+	}
 
 	return &SessionCollector{
 		openclawHome: openclawHome,
+		location:     location,
+		skills:       skills,
 		sessionActive: prometheus.NewDesc(
 			"openclaw_session_active",
 			"Number of active sessions",
@@ -130,6 +159,61 @@ func NewSessionCollector(openclawHome string) *SessionCollector {
 			"Total errors encountered in session",
 			[]string{"agent", "session_id"}, nil,
 		),
+		activityMessagesByHour: prometheus.NewDesc(
+			"openclaw_activity_messages_by_hour_total",
+			"Total transcript messages by hour of day",
+			[]string{"agent", "hour"}, nil,
+		),
+		activityTokensByHour: prometheus.NewDesc(
+			"openclaw_activity_tokens_by_hour_total",
+			"Total tokens (input + output + cache) by hour of day",
+			[]string{"agent", "hour"}, nil,
+		),
+		activityMessagesByWeekday: prometheus.NewDesc(
+			"openclaw_activity_messages_by_weekday_total",
+			"Total transcript messages by day of week",
+			[]string{"agent", "weekday"}, nil,
+		),
+		activityTokensByWeekday: prometheus.NewDesc(
+			"openclaw_activity_tokens_by_weekday_total",
+			"Total tokens (input + output + cache) by day of week",
+			[]string{"agent", "weekday"}, nil,
+		),
+		heartbeatRuns: prometheus.NewDesc(
+			"openclaw_heartbeat_runs_total",
+			"Total heartbeat-triggered turns found in session transcripts",
+			[]string{"agent"}, nil,
+		),
+		heartbeatCost: prometheus.NewDesc(
+			"openclaw_heartbeat_cost_total",
+			"Total cost in USD of heartbeat-triggered turns",
+			[]string{"agent"}, nil,
+		),
+		heartbeatOutcomes: prometheus.NewDesc(
+			"openclaw_heartbeat_outcomes_total",
+			"Total heartbeat turns by outcome (ack=silent HEARTBEAT_OK, message=outbound message)",
+			[]string{"agent", "outcome"}, nil,
+		),
+		heartbeatLastRun: prometheus.NewDesc(
+			"openclaw_heartbeat_last_run_timestamp_seconds",
+			"Timestamp of the most recent heartbeat turn in seconds since epoch",
+			[]string{"agent"}, nil,
+		),
+		heartbeatSinceLastRun: prometheus.NewDesc(
+			"openclaw_heartbeat_since_last_run_seconds",
+			"Seconds elapsed since the most recent heartbeat turn",
+			[]string{"agent"}, nil,
+		),
+		skillUsage: prometheus.NewDesc(
+			"openclaw_skill_usage_total",
+			"Times a skill's SKILL.md was read or a skill command was run in the current sessions",
+			[]string{"agent", "skill"}, nil,
+		),
+		skillLastUsed: prometheus.NewDesc(
+			"openclaw_skill_last_used_timestamp_seconds",
+			"Last time a skill was used in seconds since epoch",
+			[]string{"agent", "skill"}, nil,
+		),
 		scrapeSuccess: prometheus.NewDesc(
 			"openclaw_session_scrape_success",
 			"Whether session scrape was successful",
@@ -154,6 +238,17 @@ func (c *SessionCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.sessionErrors
 	ch <- c.modelInfo
 	ch <- c.thinkingLevel
+	ch <- c.activityMessagesByHour
+	ch <- c.activityTokensByHour
+	ch <- c.activityMessagesByWeekday
+	ch <- c.activityTokensByWeekday
+	ch <- c.heartbeatRuns
+	ch <- c.heartbeatCost
+	ch <- c.heartbeatOutcomes
+	ch <- c.heartbeatLastRun
+	ch <- c.heartbeatSinceLastRun
+	ch <- c.skillUsage
+	ch <- c.skillLastUsed
 	ch <- c.scrapeSuccess
 }
 
@@ -161,57 +256,73 @@ func (c *SessionCollector) Describe(ch chan<- *prometheus.Desc) {
 func (c *SessionCollector) Collect(ch chan<- prometheus.Metric) {
 	agentsDir := filepath.Join(c.openclawHome, "agents")
 
-	// List agent directories
-	agentEntries, err := os.ReadDir(agentsDir)
+	// Config errors are reported by the config collector
+	var cfg *openclawConfig
+	heartbeatPrompt := ""
+	if loaded, err := loadOpenclawConfig(c.openclawHome); err == nil {
+		cfg = &loaded.config
+		heartbeatPrompt = cfg.Agents.Defaults.Heartbeat.Prompt
+	}
+
+	agents, err := discoverAgentsWithConfig(c.openclawHome, cfg)
 	if err != nil {
+		log.Printf("Error discovering agents: %v", err)
+		ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 0, "unknown")
+		return
+	}
+
+	// Agents listed in the config have no sessions until openclaw creates
+	// agents/, so report the missing directory as a failed scrape
+	if _, err := os.Stat(agentsDir); err != nil {
 		log.Printf("Error reading agents directory: %v", err)
 		ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 0, "unknown")
 		return
 	}
 
-	for _, agentEntry := range agentEntries {
-		if !agentEntry.IsDir() {
-			continue
-		}
-		agentName := agentEntry.Name()
+	for _, agent := range agents {
+		agentName := agent.id
 
 		sessionsFile := filepath.Join(agentsDir, agentName, "sessions", "sessions.json")
 		if _, err := os.Stat(sessionsFile); err != nil {
 			continue
 		}
 
-		c.collectAgentSessions(ch, agentName, sessionsFile)
+		c.collectAgentSessions(ch, agentName, sessionsFile, heartbeatPrompt)
 	}
 }
 
 // sessionsJSON represents the sessions.json structure
 type sessionsJSON map[string]struct {
-	SessionID      string `json:"sessionId"`
-	UpdatedAt      int64  `json:"updatedAt"`
-	SessionFile    string `json:"sessionFile"`
-	CompactionCount int   `json:"compactionCount"`
+	SessionID       string `json:"sessionId"`
+	UpdatedAt       int64  `json:"updatedAt"`
+	SessionFile     string `json:"sessionFile"`
+	CompactionCount int    `json:"compactionCount"`
 }
 
 // sessionEvent represents an event in the session jsonl file
 type sessionEvent struct {
-	Type           string `json:"type"`
-	ID             string `json:"id"`
-	Provider       string `json:"provider"`
-	ModelID        string `json:"modelId"`
-	ThinkingLevel  string `json:"thinkingLevel"`
-	Error          *struct {
+	Type          string         `json:"type"`
+	ID            string         `json:"id"`
+	Provider      string         `json:"provider"`
+	ModelID       string         `json:"modelId"`
+	ThinkingLevel string         `json:"thinkingLevel"`
+	Timestamp     eventTimestamp `json:"timestamp"`
+	Error         *struct {
 		Message string `json:"message"`
 		Code    string `json:"code"`
 	} `json:"error"`
-	Message        *struct {
-		Provider string `json:"provider"`
-		Model    string `json:"model"`
-		Usage    *struct {
-			Input       int     `json:"input"`
-			Output      int     `json:"output"`
-			CacheRead   int     `json:"cacheRead"`
-			CacheWrite  int     `json:"cacheWrite"`
-			TotalTokens int     `json:"totalTokens"`
+	Message *struct {
+		Role      string          `json:"role"`
+		Content   json.RawMessage `json:"content"`
+		Provider  string          `json:"provider"`
+		Model     string          `json:"model"`
+		Timestamp eventTimestamp  `json:"timestamp"`
+		Usage     *struct {
+			Input       int `json:"input"`
+			Output      int `json:"output"`
+			CacheRead   int `json:"cacheRead"`
+			CacheWrite  int `json:"cacheWrite"`
+			TotalTokens int `json:"totalTokens"`
 			Cost        *struct {
 				Total float64 `json:"total"`
 			} `json:"cost"`
@@ -219,7 +330,177 @@ type sessionEvent struct {
 	} `json:"message"`
 }
 
-func (c *SessionCollector) collectAgentSessions(ch chan<- prometheus.Metric, agentName, sessionsFile string) {
+// eventTimestamp accepts both the ISO-8601 strings used on transcript events
+// and the epoch milliseconds used on embedded messages. Unrecognised values
+// are left as the zero time rather than failing the whole event.
+type eventTimestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *eventTimestamp) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), `"`)
+	if raw == "" || raw == "null" {
+		return nil
+	}
+
+	if ms, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		t.Time = time.UnixMilli(ms)
+		return nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		t.Time = parsed
+	}
+
+	return nil
+}
+
+// agentTotals accumulates transcript statistics across all sessions of an agent.
+type agentTotals struct {
+	activity   agentActivity
+	heartbeats agentHeartbeats
+	skillUsage map[string]*skillUsage
+
+	// heartbeatPrompt is the configured heartbeat prompt, if any
+	heartbeatPrompt string
+	// resolveSkill maps tool call paths to the agent's skills
+	resolveSkill skillResolver
+}
+
+// agentActivity accumulates message and token counts by hour of day and day
+// of week across all sessions of an agent.
+type agentActivity struct {
+	messagesByHour    [24]float64
+	tokensByHour      [24]float64
+	messagesByWeekday [7]float64
+	tokensByWeekday   [7]float64
+}
+
+func (a *agentActivity) observe(ts time.Time, tokens int) {
+	hour := ts.Hour()
+	weekday := ts.Weekday()
+
+	a.messagesByHour[hour]++
+	a.tokensByHour[hour] += float64(tokens)
+	a.messagesByWeekday[weekday]++
+	a.tokensByWeekday[weekday] += float64(tokens)
+}
+
+func (c *SessionCollector) collectAgentActivity(ch chan<- prometheus.Metric, agentName string, activity *agentActivity) {
+	for hour := 0; hour < 24; hour++ {
+		label := fmt.Sprintf("%02d", hour)
+		ch <- prometheus.MustNewConstMetric(
+			c.activityMessagesByHour,
+			prometheus.GaugeValue,
+			activity.messagesByHour[hour],
+			agentName, label,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.activityTokensByHour,
+			prometheus.GaugeValue,
+			activity.tokensByHour[hour],
+			agentName, label,
+		)
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		ch <- prometheus.MustNewConstMetric(
+			c.activityMessagesByWeekday,
+			prometheus.GaugeValue,
+			activity.messagesByWeekday[weekday],
+			agentName, weekday.String(),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.activityTokensByWeekday,
+			prometheus.GaugeValue,
+			activity.tokensByWeekday[weekday],
+			agentName, weekday.String(),
+		)
+	}
+}
+
+func (c *SessionCollector) collectAgentHeartbeats(ch chan<- prometheus.Metric, agentName string, heartbeats *agentHeartbeats) {
+	ch <- prometheus.MustNewConstMetric(
+		c.heartbeatRuns,
+		prometheus.GaugeValue,
+		heartbeats.runs,
+		agentName,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.heartbeatCost,
+		prometheus.GaugeValue,
+		heartbeats.cost,
+		agentName,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.heartbeatOutcomes,
+		prometheus.GaugeValue,
+		heartbeats.acks,
+		agentName, "ack",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.heartbeatOutcomes,
+		prometheus.GaugeValue,
+		heartbeats.messages,
+		agentName, "message",
+	)
+
+	// Only report timing once a heartbeat has been seen
+	if heartbeats.lastRun.IsZero() {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.heartbeatLastRun,
+		prometheus.GaugeValue,
+		float64(heartbeats.lastRun.Unix()),
+		agentName,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.heartbeatSinceLastRun,
+		prometheus.GaugeValue,
+		time.Since(heartbeats.lastRun).Seconds(),
+		agentName,
+	)
+}
+
+func (c *SessionCollector) collectAgentSkillUsage(ch chan<- prometheus.Metric, agentName string, usage map[string]*skillUsage) {
+	// Report installed skills that were never used as zero
+	if c.skills != nil {
+		for _, skill := range c.skills.EffectiveSkills(agentName) {
+			if _, ok := usage[skill]; !ok {
+				usage[skill] = &skillUsage{}
+			}
+		}
+	}
+
+	for skill, u := range usage {
+		ch <- prometheus.MustNewConstMetric(
+			c.skillUsage,
+			prometheus.GaugeValue,
+			u.count,
+			agentName, skill,
+		)
+
+		if u.lastUsed.IsZero() {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.skillLastUsed,
+			prometheus.GaugeValue,
+			float64(u.lastUsed.Unix()),
+			agentName, skill,
+		)
+	}
+}
+
+func (c *SessionCollector) collectAgentSessions(ch chan<- prometheus.Metric, agentName, sessionsFile, heartbeatPrompt string) {
 	// Read sessions.json
 	data, err := os.ReadFile(sessionsFile)
 	if err != nil {
@@ -233,6 +514,15 @@ func (c *SessionCollector) collectAgentSessions(ch chan<- prometheus.Metric, age
 		return
 	}
 
+	totals := &agentTotals{skillUsage: make(map[string]*skillUsage), heartbeatPrompt: heartbeatPrompt}
+	totals.resolveSkill = func(path string) (string, bool) {
+		// Without a skill inventory no path is known to belong to a skill
+		if c.skills == nil {
+			return "", false
+		}
+		return c.skills.SkillForPath(agentName, path)
+	}
+
 	for key, session := range sessions {
 		// Only process "agent:main:main" style keys (active sessions)
 		if !strings.HasPrefix(key, "agent:") {
@@ -262,14 +552,18 @@ func (c *SessionCollector) collectAgentSessions(ch chan<- prometheus.Metric, age
 
 		// Parse session file for detailed metrics
 		if session.SessionFile != "" {
-			c.collectSessionFileMetrics(ch, agentName, sessionID, session.SessionFile)
+			c.collectSessionFileMetrics(ch, agentName, sessionID, session.SessionFile, totals)
 		}
 	}
 
+	c.collectAgentActivity(ch, agentName, &totals.activity)
+	c.collectAgentHeartbeats(ch, agentName, &totals.heartbeats)
+	c.collectAgentSkillUsage(ch, agentName, totals.skillUsage)
+
 	ch <- prometheus.MustNewConstMetric(c.scrapeSuccess, prometheus.GaugeValue, 1, agentName)
 }
 
-func (c *SessionCollector) collectSessionFileMetrics(ch chan<- prometheus.Metric, agentName, sessionID, sessionFile string) {
+func (c *SessionCollector) collectSessionFileMetrics(ch chan<- prometheus.Metric, agentName, sessionID, sessionFile string, totals *agentTotals) {
 	file, err := os.Open(sessionFile)
 	if err != nil {
 		log.Printf("Error opening session file %s: %v", sessionFile, err)
@@ -278,20 +572,22 @@ func (c *SessionCollector) collectSessionFileMetrics(ch chan<- prometheus.Metric
 	defer file.Close()
 
 	var (
-		messageCount       int
-		totalInputTokens   int
-		totalOutputTokens  int
-		totalCacheRead     int
-		totalCacheWrite    int
-		totalCost          float64
-		currentProvider    string
-		currentModel       string
-		thinkingLevelNum   float64
-		errorCount         int
-		firstTimestamp     int64
-		lastTimestamp      int64
+		messageCount      int
+		totalInputTokens  int
+		totalOutputTokens int
+		totalCacheRead    int
+		totalCacheWrite   int
+		totalCost         float64
+		currentProvider   string
+		currentModel      string
+		thinkingLevelNum  float64
+		errorCount        int
+		firstTimestamp    int64
+		lastTimestamp     int64
 	)
 
+	heartbeat := &heartbeatTracker{stats: &totals.heartbeats, prompt: totals.heartbeatPrompt}
+
 	scanner := bufio.NewScanner(file)
 	// Increase buffer size for large lines
 	buf := make([]byte, 0, 64*1024)
@@ -311,6 +607,8 @@ func (c *SessionCollector) collectSessionFileMetrics(ch chan<- prometheus.Metric
 		switch event.Type {
 		case "message":
 			messageCount++
+			messageTokens := 0
+			messageCost := 0.0
 			if event.Message != nil {
 				// Get model from message
 				if event.Message.Model != "" {
@@ -325,11 +623,34 @@ func (c *SessionCollector) collectSessionFileMetrics(ch chan<- prometheus.Metric
 					totalOutputTokens += event.Message.Usage.Output
 					totalCacheRead += event.Message.Usage.CacheRead
 					totalCacheWrite += event.Message.Usage.CacheWrite
+					messageTokens = event.Message.Usage.Input + event.Message.Usage.Output +
+						event.Message.Usage.CacheRead + event.Message.Usage.CacheWrite
 					if event.Message.Usage.Cost != nil {
-						totalCost += event.Message.Usage.Cost.Total
+						messageCost = event.Message.Usage.Cost.Total
+						totalCost += messageCost
 					}
 				}
 			}
+
+			// Bucket activity by the event time, falling back to the message time
+			ts := event.Timestamp.Time
+			if ts.IsZero() && event.Message != nil {
+				ts = event.Message.Timestamp.Time
+			}
+			if !ts.IsZero() {
+				totals.activity.observe(ts.In(c.location), messageTokens)
+			}
+
+			// Attribute turns to heartbeat polls and track skill usage
+			if event.Message != nil {
+				switch event.Message.Role {
+				case "user":
+					heartbeat.userMessage(messageText(event.Message.Content), ts)
+				case "assistant":
+					heartbeat.assistantMessage(messageText(event.Message.Content), messageCost)
+					observeSkillUsage(totals.skillUsage, event.Message.Content, ts, totals.resolveSkill)
+				}
+			}
 			// Track errors in messages
 			if event.Error != nil {
 				errorCount++
@@ -369,6 +690,7 @@ func (c *SessionCollector) collectSessionFileMetrics(ch chan<- prometheus.Metric
 		// For now, we'll use message count as a proxy
 		lastTimestamp++
 	}
+	heartbeat.finish()
 
 	// Report metrics
 	ch <- prometheus.MustNewConstMetric(
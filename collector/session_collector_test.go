@@ -0,0 +1,87 @@
+package collector
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestEventTimestampUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want time.Time
+	}{
+		{"epoch milliseconds", `1773485400123`, time.UnixMilli(1773485400123)},
+		{"quoted epoch milliseconds", `"1773485400123"`, time.UnixMilli(1773485400123)},
+		{"RFC 3339", `"2026-03-14T10:50:00Z"`, time.Date(2026, 3, 14, 10, 50, 0, 0, time.UTC)},
+		{"RFC 3339 with fraction and offset", `"2026-03-14T18:50:00.123+08:00"`, time.Date(2026, 3, 14, 10, 50, 0, 123000000, time.UTC)},
+		{"null", `null`, time.Time{}},
+		{"empty string", `""`, time.Time{}},
+		{"unrecognised", `"yesterday"`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var event struct {
+				Timestamp eventTimestamp `json:"timestamp"`
+			}
+			if err := json.Unmarshal([]byte(`{"timestamp": `+tt.json+`}`), &event); err != nil {
+				t.Fatalf("Unmarshal returned %v", err)
+			}
+			if !event.Timestamp.Equal(tt.want) {
+				t.Errorf("timestamp = %v, want %v", event.Timestamp.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionCollectorMissingAgentsDir(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{"no config", ""},
+		{"agents listed in the config", `{ agents: { list: [{ id: "main" }] } }`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("OPENCLAW_CONFIG_PATH", "")
+			if tt.config != "" {
+				if err := os.WriteFile(filepath.Join(home, openclawConfigFile), []byte(tt.config), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(NewSessionCollector(home, time.UTC, nil))
+			families, err := registry.Gather()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var found bool
+			for _, family := range families {
+				if family.GetName() != "openclaw_session_scrape_success" {
+					continue
+				}
+				for _, metric := range family.GetMetric() {
+					found = true
+					labels := metric.GetLabel()
+					if len(labels) != 1 || labels[0].GetValue() != "unknown" || metric.GetGauge().GetValue() != 0 {
+						t.Errorf("scrape success = %v %v, want 0 for agent unknown", labels, metric.GetGauge().GetValue())
+					}
+				}
+			}
+			if !found {
+				t.Error("no openclaw_session_scrape_success reported without an agents directory")
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package collector
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Platform names used by openclaw (Node's process.platform) for GOOS values
+// that differ.
+var skillPlatforms = map[string]string{
+	"windows": "win32",
+}
+
+// evaluateSkillEligibility checks each skill's declared requirements against
+// this host and the gateway config, mirroring the checks openclaw makes
+// before loading a skill. cfg may be nil.
+func evaluateSkillEligibility(skills []skillInfo, cfg *loadedConfig) {
+	for i := range skills {
+		skills[i].missingRequirement = missingSkillRequirement(&skills[i], cfg)
+	}
+}
+
+// missingSkillRequirement returns the first requirement the skill does not
+// meet, as "kind:value", or "" if the skill is eligible.
+func missingSkillRequirement(skill *skillInfo, cfg *loadedConfig) string {
+	metadata := &skill.metadata
+
+	var entry configSkillEntry
+	if cfg != nil {
+		key := metadata.SkillKey
+		if key == "" {
+			key = skill.name
+		}
+		entry = cfg.config.Skills.Entries[key]
+	}
+
+	if entry.Enabled != nil && !*entry.Enabled {
+		return "disabled"
+	}
+
+	if len(metadata.OS) > 0 {
+		platform := runtime.GOOS
+		if mapped, ok := skillPlatforms[platform]; ok {
+			platform = mapped
+		}
+		if !slices.Contains(metadata.OS, platform) {
+			return "os:" + strings.Join(metadata.OS, "|")
+		}
+	}
+
+	// Always-on skills skip the remaining requirement checks
+	if metadata.Always {
+		return ""
+	}
+
+	for _, bin := range metadata.Requires.Bins {
+		if _, err := exec.LookPath(bin); err != nil {
+			return "bin:" + bin
+		}
+	}
+
+	if len(metadata.Requires.AnyBins) > 0 {
+		found := false
+		for _, bin := range metadata.Requires.AnyBins {
+			if _, err := exec.LookPath(bin); err == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "any_bin:" + strings.Join(metadata.Requires.AnyBins, "|")
+		}
+	}
+
+	for _, name := range metadata.Requires.Env {
+		if os.Getenv(name) != "" || entry.Env[name] != "" {
+			continue
+		}
+		// An apiKey in the skill's config entry provides its primary env var
+		if name == metadata.PrimaryEnv && hasConfigValue(entry.APIKey) {
+			continue
+		}
+		return "env:" + name
+	}
+
+	for _, path := range metadata.Requires.Config {
+		if cfg == nil {
+			return "config:" + path
+		}
+		if value, ok := cfg.lookup(path); !ok || !isTruthy(value) {
+			return "config:" + path
+		}
+	}
+
+	return ""
+}
+
+// hasConfigValue reports whether a raw config value is set and not null or
+// an empty string.
+func hasConfigValue(raw []byte) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && string(raw) != "null" && string(raw) != `""`
+}
+
+// isTruthy follows JavaScript truthiness for decoded JSON values, except that
+// empty objects and arrays count as unset.
+func isTruthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
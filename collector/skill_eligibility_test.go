@@ -0,0 +1,112 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"go.yaml.in/yaml/v2"
+)
+
+func TestMissingSkillRequirement(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake binaries need an executable bit")
+	}
+
+	bins := t.TempDir()
+	for _, name := range []string{"curl", "chromium"} {
+		if err := os.WriteFile(filepath.Join(bins, name), []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", bins)
+	t.Setenv("SKILL_TEST_SET", "1")
+	t.Setenv("SKILL_TEST_UNSET", "")
+
+	home := t.TempDir()
+	t.Setenv("OPENCLAW_CONFIG_PATH", "")
+	config := `{
+  browser: { enabled: true, profile: "" },
+  tools: { list: [], limits: {} },
+  skills: {
+    entries: {
+      off: { enabled: false },
+      on: { enabled: true },
+      keyed: { env: { SKILL_TEST_UNSET: "from-config" } },
+      brave: { apiKey: "secret" },
+      nullkey: { apiKey: null },
+    },
+  },
+}`
+	if err := os.WriteFile(filepath.Join(home, openclawConfigFile), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadOpenclawConfig(home)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	platform := runtime.GOOS
+	if mapped, ok := skillPlatforms[platform]; ok {
+		platform = mapped
+	}
+	otherPlatform := "plan9"
+	if platform == otherPlatform {
+		otherPlatform = "darwin"
+	}
+
+	tests := []struct {
+		name     string
+		skill    string
+		metadata string
+		noConfig bool
+		want     string
+	}{
+		{"no requirements", "web", "", false, ""},
+		{"disabled", "off", "requires:\n  bins: [curl]\n", false, "disabled"},
+		{"disabled before os", "off", "os: [" + otherPlatform + "]\n", false, "disabled"},
+		{"explicitly enabled", "on", "", false, ""},
+		{"disabled by skill key", "web", "skillKey: off\n", false, "disabled"},
+		{"current os", "web", "os: [" + platform + ", " + otherPlatform + "]\n", false, ""},
+		{"other os", "web", "os: [" + otherPlatform + "]\n", false, "os:" + otherPlatform},
+		{"always skips requirements", "web", "always: true\nrequires:\n  bins: [ffmpeg]\n  env: [SKILL_TEST_UNSET]\n", false, ""},
+		{"always still checks os", "web", "always: true\nos: [" + otherPlatform + "]\n", false, "os:" + otherPlatform},
+		{"bins present", "web", "requires:\n  bins: [curl, chromium]\n", false, ""},
+		{"first missing bin", "web", "requires:\n  bins: [curl, ffmpeg, jq]\n", false, "bin:ffmpeg"},
+		{"any bin present", "web", "requires:\n  anyBins: [chrome, chromium]\n", false, ""},
+		{"no any bin", "web", "requires:\n  anyBins: [chrome, firefox]\n", false, "any_bin:chrome|firefox"},
+		{"env set", "web", "requires:\n  env: [SKILL_TEST_SET]\n", false, ""},
+		{"env empty", "web", "requires:\n  env: [SKILL_TEST_UNSET]\n", false, "env:SKILL_TEST_UNSET"},
+		{"env from config entry", "keyed", "requires:\n  env: [SKILL_TEST_UNSET]\n", false, ""},
+		{"primary env from api key", "brave", "primaryEnv: SKILL_TEST_UNSET\nrequires:\n  env: [SKILL_TEST_UNSET]\n", false, ""},
+		{"api key only provides the primary env", "brave", "primaryEnv: BRAVE_API_KEY\nrequires:\n  env: [SKILL_TEST_UNSET]\n", false, "env:SKILL_TEST_UNSET"},
+		{"null api key", "nullkey", "primaryEnv: SKILL_TEST_UNSET\nrequires:\n  env: [SKILL_TEST_UNSET]\n", false, "env:SKILL_TEST_UNSET"},
+		{"config truthy", "web", "requires:\n  config: [browser.enabled]\n", false, ""},
+		{"config empty string", "web", "requires:\n  config: [browser.profile]\n", false, "config:browser.profile"},
+		{"config empty array", "web", "requires:\n  config: [tools.list]\n", false, "config:tools.list"},
+		{"config empty object", "web", "requires:\n  config: [tools.limits]\n", false, "config:tools.limits"},
+		{"config missing", "web", "requires:\n  config: [browser.headless]\n", false, "config:browser.headless"},
+		{"config path through a scalar", "web", "requires:\n  config: [browser.enabled.value]\n", false, "config:browser.enabled.value"},
+		{"config without a config file", "web", "requires:\n  config: [browser.enabled]\n", true, "config:browser.enabled"},
+		{"no config file", "off", "requires:\n  bins: [curl]\n", true, ""},
+		{"bins checked before env", "web", "requires:\n  bins: [ffmpeg]\n  env: [SKILL_TEST_UNSET]\n", false, "bin:ffmpeg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skill := skillInfo{name: tt.skill}
+			if err := yaml.Unmarshal([]byte(tt.metadata), &skill.metadata); err != nil {
+				t.Fatal(err)
+			}
+
+			skillCfg := cfg
+			if tt.noConfig {
+				skillCfg = nil
+			}
+			if got := missingSkillRequirement(&skill, skillCfg); got != tt.want {
+				t.Errorf("missingSkillRequirement = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package collector
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+)
+
+// SkillInventory lists the skills available to each agent, so that skills
+// without any recorded usage are still reported, and maps transcript paths
+// to the skills they belong to.
+type SkillInventory interface {
+	EffectiveSkills(agent string) []string
+	// SkillForPath returns the name of the skill whose directory contains
+	// path. Relative paths are resolved against the agent's workspaces.
+	SkillForPath(agent, path string) (string, bool)
+}
+
+// skillResolver maps a path from a tool call to a skill name.
+type skillResolver func(path string) (string, bool)
+
+// commandSeparators splits a command line into words that may be paths.
+const commandSeparators = " \t\n;&|<>()'\"`="
+
+// skillUsage records how often and when an agent last used a skill.
+type skillUsage struct {
+	count    float64
+	lastUsed time.Time
+}
+
+// toolCall is a tool invocation content block of an assistant message.
+type toolCall struct {
+	Name      string `json:"name"`
+	Arguments struct {
+		Path     string `json:"path"`
+		FilePath string `json:"file_path"`
+		Command  string `json:"command"`
+	} `json:"arguments"`
+}
+
+// messageToolCalls extracts the tool calls from transcript message content.
+func messageToolCalls(content json.RawMessage) []toolCall {
+	var blocks []struct {
+		Type string `json:"type"`
+		toolCall
+	}
+	if err := json.Unmarshal(content, &blocks); err != nil {
+		return nil
+	}
+
+	var calls []toolCall
+	for _, block := range blocks {
+		if block.Type == "toolCall" || block.Type == "tool_use" {
+			calls = append(calls, block.toolCall)
+		}
+	}
+
+	return calls
+}
+
+// usedSkills returns the skills a tool call uses: reading a skill's SKILL.md
+// or running a command that refers to a path inside a skill directory.
+// Paths outside the known skill directories are ignored.
+func (t toolCall) usedSkills(resolve skillResolver) []string {
+	switch strings.ToLower(t.Name) {
+	case "read":
+		file := t.Arguments.Path
+		if file == "" {
+			file = t.Arguments.FilePath
+		}
+		if path.Base(strings.ReplaceAll(file, `\`, "/")) != "SKILL.md" {
+			return nil
+		}
+		if skill, ok := resolve(file); ok {
+			return []string{skill}
+		}
+
+	case "exec", "bash":
+		var skills []string
+		words := strings.FieldsFunc(t.Arguments.Command, func(r rune) bool {
+			return strings.ContainsRune(commandSeparators, r)
+		})
+		for _, word := range words {
+			if !strings.ContainsAny(word, `/\`) {
+				continue
+			}
+			if skill, ok := resolve(word); ok {
+				skills = append(skills, skill)
+			}
+		}
+		return skills
+	}
+
+	return nil
+}
+
+// observeSkillUsage records the skills used by the tool calls of a message.
+func observeSkillUsage(usage map[string]*skillUsage, content json.RawMessage, ts time.Time, resolve skillResolver) {
+	for _, call := range messageToolCalls(content) {
+		// Count each skill once per call even if a command mentions it twice
+		seen := make(map[string]bool)
+		for _, skill := range call.usedSkills(resolve) {
+			if seen[skill] {
+				continue
+			}
+			seen[skill] = true
+
+			u, ok := usage[skill]
+			if !ok {
+				u = &skillUsage{}
+				usage[skill] = u
+			}
+			u.count++
+			if ts.After(u.lastUsed) {
+				u.lastUsed = ts
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package collector
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSkillForPath(t *testing.T) {
+	workspace := "/home/user/.openclaw/workspace"
+	t.Setenv("HOME", "/home/user")
+
+	c := &OpenclawCollector{}
+	c.snapshot.workspaces = []workspaceSnapshot{
+		{
+			workspace: Workspace{Agent: "main", Dir: workspace},
+			skills: []skillInfo{
+				{name: "weather", source: skillSourceWorkspace, dir: filepath.Join(workspace, "skills", "weather")},
+				{name: "gh-issues", source: skillSourceUser, dir: "/home/user/.openclaw/skills/github"},
+				{name: "notes", source: skillSourceLegacy, dir: filepath.Join(workspace, "skill.md")},
+			},
+		},
+		{
+			workspace: Workspace{Agent: "work", Dir: "/home/user/.openclaw/workspace-work"},
+			skills: []skillInfo{
+				{name: "deploy", source: skillSourceWorkspace, dir: "/home/user/.openclaw/workspace-work/skills/deploy"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		agent     string
+		path      string
+		wantSkill string
+		wantOK    bool
+	}{
+		{"absolute SKILL.md", "main", workspace + "/skills/weather/SKILL.md", "weather", true},
+		{"relative to workspace", "main", "skills/weather/scripts/fetch.py", "weather", true},
+		{"dot relative", "main", "./skills/weather", "weather", true},
+		{"home relative", "main", "~/.openclaw/skills/github/SKILL.md", "gh-issues", true},
+		{"frontmatter name differs from directory", "main", "/home/user/.openclaw/skills/github", "gh-issues", true},
+		{"unrelated skills directory", "main", "/srv/project/skills/weather/run.sh", "", false},
+		{"directory name prefix", "main", workspace + "/skills/weather-old/SKILL.md", "", false},
+		{"skills root itself", "main", workspace + "/skills", "", false},
+		{"legacy skill file", "main", workspace + "/skill.md", "", false},
+		{"escaping the skill directory", "main", "skills/weather/../../SOUL.md", "", false},
+		{"other agent's skill", "main", "/home/user/.openclaw/workspace-work/skills/deploy/SKILL.md", "", false},
+		{"unknown agent", "ops", workspace + "/skills/weather/SKILL.md", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skill, ok := c.SkillForPath(tt.agent, tt.path)
+			if skill != tt.wantSkill || ok != tt.wantOK {
+				t.Errorf("SkillForPath(%q, %q) = %q, %v, want %q, %v", tt.agent, tt.path, skill, ok, tt.wantSkill, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestObserveSkillUsage(t *testing.T) {
+	skillDirs := map[string]string{
+		"/ws/skills/weather": "weather",
+		"/ws/skills/github":  "gh-issues",
+	}
+	resolve := func(p string) (string, bool) {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join("/ws", p)
+		}
+		p = filepath.Clean(p)
+		for dir, name := range skillDirs {
+			if p == dir || filepath.Dir(p) == dir || filepath.Dir(filepath.Dir(p)) == dir {
+				return name, true
+			}
+		}
+		return "", false
+	}
+
+	tests := []struct {
+		name  string
+		calls string
+		want  map[string]float64
+	}{
+		{
+			name:  "read SKILL.md",
+			calls: `[{"type":"toolCall","name":"read","arguments":{"path":"/ws/skills/weather/SKILL.md"}}]`,
+			want:  map[string]float64{"weather": 1},
+		},
+		{
+			name:  "read uses file_path",
+			calls: `[{"type":"tool_use","name":"Read","arguments":{"file_path":"skills/github/SKILL.md"}}]`,
+			want:  map[string]float64{"gh-issues": 1},
+		},
+		{
+			name:  "read other file in skill",
+			calls: `[{"type":"toolCall","name":"read","arguments":{"path":"/ws/skills/weather/README.md"}}]`,
+			want:  map[string]float64{},
+		},
+		{
+			name:  "exec script in skill",
+			calls: `[{"type":"toolCall","name":"exec","arguments":{"command":"python skills/weather/fetch.py --city=Paris"}}]`,
+			want:  map[string]float64{"weather": 1},
+		},
+		{
+			name:  "exec quoted and chained paths",
+			calls: `[{"type":"toolCall","name":"bash","arguments":{"command":"cd '/ws/skills/github' && ./run.sh; cat \"skills/weather/data.json\""}}]`,
+			want:  map[string]float64{"gh-issues": 1, "weather": 1},
+		},
+		{
+			name:  "same skill twice in one call",
+			calls: `[{"type":"toolCall","name":"exec","arguments":{"command":"skills/weather/a.sh | skills/weather/b.sh"}}]`,
+			want:  map[string]float64{"weather": 1},
+		},
+		{
+			name:  "unrelated skills path",
+			calls: `[{"type":"toolCall","name":"exec","arguments":{"command":"ls /srv/app/skills/weather/"}}]`,
+			want:  map[string]float64{},
+		},
+		{
+			name:  "separate calls",
+			calls: `[{"type":"toolCall","name":"read","arguments":{"path":"skills/weather/SKILL.md"}},{"type":"toolCall","name":"exec","arguments":{"command":"skills/weather/fetch.sh"}}]`,
+			want:  map[string]float64{"weather": 2},
+		},
+		{
+			name:  "text content",
+			calls: `[{"type":"text","text":"see skills/weather/SKILL.md"}]`,
+			want:  map[string]float64{},
+		},
+	}
+
+	ts := time.Unix(1700000000, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage := make(map[string]*skillUsage)
+			observeSkillUsage(usage, json.RawMessage(tt.calls), ts, resolve)
+
+			got := make(map[string]float64)
+			for skill, u := range usage {
+				got[skill] = u.count
+				if !u.lastUsed.Equal(ts) {
+					t.Errorf("skill %s lastUsed = %v, want %v", skill, u.lastUsed, ts)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("usage = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package collector
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v2"
+)
+
+// Skill sources, from the legacy skill.md file to the openclaw package
+const (
+	skillSourceLegacy    = "legacy"
+	skillSourceWorkspace = "workspace"
+	skillSourceUser      = "user"
+	skillSourceSystem    = "system"
+)
+
+// skillPrecedence orders skill sources from highest to lowest precedence:
+// a workspace skill overrides a user skill of the same name, which overrides
+// the system one. Legacy skill.md sections only apply when nothing else does.
+var skillPrecedence = map[string]int{
+	skillSourceWorkspace: 0,
+	skillSourceUser:      1,
+	skillSourceSystem:    2,
+	skillSourceLegacy:    3,
+}
+
+// skillInfo describes a single skill found in one of the skill sources.
+type skillInfo struct {
+	name   string
+	source string
+	dir    string
+
+	// effective is set for the copy of a skill the agent loads; other
+	// copies record the source of the effective copy in shadowedBy.
+	effective  bool
+	shadowedBy string
+
+	// invalidReason is empty when the SKILL.md frontmatter is valid.
+	invalidReason string
+	metadata      skillMetadata
+
+	// missingRequirement is empty when the skill's requirements are met.
+	missingRequirement string
+}
+
+// Reasons a SKILL.md fails validation, in the order they are checked
+const (
+	skillInvalidMissingFrontmatter = "missing_frontmatter"
+	skillInvalidMalformedYAML      = "malformed_yaml"
+	skillInvalidMissingName        = "missing_name"
+	skillInvalidName               = "invalid_name"
+	skillInvalidNameMismatch       = "name_mismatch"
+	skillInvalidMissingDescription = "missing_description"
+	skillInvalidMetadata           = "invalid_metadata"
+)
+
+// skillNamePattern is the AgentSkills naming rule: lowercase letters,
+// digits and single hyphens, at most 64 characters.
+var skillNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+const skillNameMaxLength = 64
+
+// skillFrontmatter is the YAML frontmatter at the top of a SKILL.md file.
+type skillFrontmatter struct {
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description"`
+	Metadata    interface{} `yaml:"metadata"`
+}
+
+// skillMetadata is the openclaw section of the frontmatter metadata.
+type skillMetadata struct {
+	Emoji      string     `yaml:"emoji"`
+	Always     bool       `yaml:"always"`
+	OS         stringList `yaml:"os"`
+	PrimaryEnv string     `yaml:"primaryEnv"`
+	SkillKey   string     `yaml:"skillKey"`
+	Requires   struct {
+		Bins    stringList `yaml:"bins"`
+		AnyBins stringList `yaml:"anyBins"`
+		Env     stringList `yaml:"env"`
+		Config  stringList `yaml:"config"`
+	} `yaml:"requires"`
+}
+
+// stringList is a list of strings in skill metadata, where a single value
+// may also be written as a scalar.
+type stringList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (l *stringList) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var single string
+	if err := unmarshal(&single); err == nil {
+		*l = nil
+		if single != "" {
+			*l = stringList{single}
+		}
+		return nil
+	}
+
+	var list []string
+	if err := unmarshal(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// scanSkillsDir returns the skills in dir, one per subdirectory containing a
+// SKILL.md. Skills are named by their frontmatter, falling back to the
+// directory name, and skills with invalid frontmatter are still returned.
+// A missing dir yields no skills.
+func scanSkillsDir(ctx context.Context, dir, source string) ([]skillInfo, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var skills []skillInfo
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if !entry.IsDir() {
+			continue
+		}
+
+		skillDir := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(filepath.Join(skillDir, "SKILL.md"))
+		if err != nil {
+			continue
+		}
+
+		skill := parseSkill(entry.Name(), data)
+		skill.source = source
+		skill.dir = skillDir
+		skills = append(skills, skill)
+	}
+
+	return skills, nil
+}
+
+// parseSkill parses and validates the frontmatter of a SKILL.md found in the
+// directory dirName. Only the first validation failure is recorded.
+func parseSkill(dirName string, data []byte) skillInfo {
+	skill := skillInfo{name: dirName}
+
+	raw, ok := extractFrontmatter(data)
+	if !ok {
+		skill.invalidReason = skillInvalidMissingFrontmatter
+		return skill
+	}
+
+	var frontmatter skillFrontmatter
+	if err := yaml.Unmarshal(raw, &frontmatter); err != nil {
+		skill.invalidReason = skillInvalidMalformedYAML
+		return skill
+	}
+
+	name := strings.TrimSpace(frontmatter.Name)
+	if name != "" {
+		skill.name = name
+	}
+
+	switch {
+	case name == "":
+		skill.invalidReason = skillInvalidMissingName
+	case len(name) > skillNameMaxLength || !skillNamePattern.MatchString(name):
+		skill.invalidReason = skillInvalidName
+	case name != dirName:
+		skill.invalidReason = skillInvalidNameMismatch
+	case strings.TrimSpace(frontmatter.Description) == "":
+		skill.invalidReason = skillInvalidMissingDescription
+	}
+
+	metadata, err := parseSkillMetadata(frontmatter.Metadata)
+	if err != nil && skill.invalidReason == "" {
+		skill.invalidReason = skillInvalidMetadata
+	}
+	skill.metadata = metadata
+
+	return skill
+}
+
+// parseSkillMetadata decodes the openclaw section of the frontmatter
+// metadata. Missing metadata is valid; metadata that is not a mapping or
+// has fields of the wrong type is not.
+func parseSkillMetadata(metadata interface{}) (skillMetadata, error) {
+	var result skillMetadata
+	if metadata == nil {
+		return result, nil
+	}
+
+	root, ok := metadata.(map[interface{}]interface{})
+	if !ok {
+		return result, fmt.Errorf("metadata is not a mapping")
+	}
+
+	section, ok := root["openclaw"]
+	if !ok || section == nil {
+		return result, nil
+	}
+
+	data, err := yaml.Marshal(section)
+	if err != nil {
+		return result, err
+	}
+
+	err = yaml.Unmarshal(data, &result)
+	return result, err
+}
+
+// resolveSkillPrecedence marks, for every skill name, the copy that wins by
+// source precedence as effective. Within a source the first directory in
+// lexical order wins.
+func resolveSkillPrecedence(skills []skillInfo) {
+	winners := make(map[string]int)
+
+	for i := range skills {
+		skills[i].effective = false
+		skills[i].shadowedBy = ""
+
+		winner, ok := winners[skills[i].name]
+		if !ok || skillPrecedence[skills[i].source] < skillPrecedence[skills[winner].source] {
+			winners[skills[i].name] = i
+		}
+	}
+
+	for i := range skills {
+		winner := winners[skills[i].name]
+		if winner == i {
+			skills[i].effective = true
+			continue
+		}
+		skills[i].shadowedBy = skills[winner].source
+	}
+}
+
+// scanLegacySkills returns one skill per H2 section of a legacy skill.md.
+func scanLegacySkills(path string) ([]skillInfo, error) {
+	sections, err := markdownSections(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Repeated headings describe the same skill
+	seen := make(map[string]bool)
+	skills := make([]skillInfo, 0, len(sections))
+	for _, section := range sections {
+		if seen[section] {
+			continue
+		}
+		seen[section] = true
+
+		skills = append(skills, skillInfo{
+			name:   section,
+			source: skillSourceLegacy,
+			dir:    path,
+		})
+	}
+
+	return skills, nil
+}
+
+// extractFrontmatter returns the YAML between the leading "---" delimiters
+// of a markdown document.
+func extractFrontmatter(data []byte) ([]byte, bool) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if !bytes.HasPrefix(data, []byte("---")) {
+		return nil, false
+	}
+
+	rest := data[3:]
+	newline := bytes.IndexByte(rest, '\n')
+	if newline < 0 || strings.TrimSpace(string(rest[:newline])) != "" {
+		return nil, false
+	}
+	rest = rest[newline+1:]
+
+	for offset := 0; offset < len(rest); {
+		end := bytes.IndexByte(rest[offset:], '\n')
+		line := rest[offset:]
+		if end >= 0 {
+			line = rest[offset : offset+end]
+		}
+		if strings.TrimRight(string(line), " \t\r") == "---" {
+			return rest[:offset], true
+		}
+		if end < 0 {
+			break
+		}
+		offset += end + 1
+	}
+
+	return nil, false
+}
+
+// markdownSections returns the titles of the H2 sections (##) in a markdown file.
+func markdownSections(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var sections []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "##") {
+			rest := strings.TrimLeft(line[2:], " \t")
+			if rest != "" {
+				sections = append(sections, strings.TrimSpace(strings.TrimLeft(rest, "#")))
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
@@ -0,0 +1,219 @@
+package collector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v2"
+)
+
+func TestExtractFrontmatter(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		want   string
+		wantOK bool
+	}{
+		{"frontmatter", "---\nname: web\n---\n# Web\n", "name: web\n", true},
+		{"empty frontmatter", "---\n---\nbody", "", true},
+		{"closing delimiter at end of file", "---\nname: web\n---", "name: web\n", true},
+		{"CRLF line endings", "---\r\nname: web\r\n---\r\nbody", "name: web\r\n", true},
+		{"byte order mark", "\ufeff---\nname: web\n---\n", "name: web\n", true},
+		{"trailing spaces on delimiters", "--- \nname: web\n---  \n", "name: web\n", true},
+		{"no frontmatter", "# Web\n", "", false},
+		{"empty file", "", "", false},
+		{"unterminated", "---\nname: web\n", "", false},
+		{"text after opening delimiter", "--- name: web\n---\n", "", false},
+		{"opening delimiter only", "---", "", false},
+		{"delimiter not at start", "\n---\nname: web\n---\n", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := extractFrontmatter([]byte(tt.data))
+			if ok != tt.wantOK {
+				t.Fatalf("extractFrontmatter(%q) ok = %v, want %v", tt.data, ok, tt.wantOK)
+			}
+			if string(got) != tt.want {
+				t.Errorf("extractFrontmatter(%q) = %q, want %q", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSkill(t *testing.T) {
+	tests := []struct {
+		name       string
+		dir        string
+		data       string
+		wantName   string
+		wantReason string
+	}{
+		{"valid", "web", "---\nname: web\ndescription: Browse the web\n---\n", "web", ""},
+		{"valid with CRLF and BOM", "web", "\ufeff---\r\nname: web\r\ndescription: Browse the web\r\n---\r\n", "web", ""},
+		{"missing frontmatter", "web", "# Web\n", "web", skillInvalidMissingFrontmatter},
+		{"unterminated frontmatter", "web", "---\nname: web\ndescription: Browse\n", "web", skillInvalidMissingFrontmatter},
+		{"malformed YAML", "web", "---\nname: [web\ndescription: Browse\n---\n", "web", skillInvalidMalformedYAML},
+		{"missing name", "web", "---\ndescription: Browse\n---\n", "web", skillInvalidMissingName},
+		{"blank name", "web", "---\nname: \"  \"\ndescription: Browse\n---\n", "web", skillInvalidMissingName},
+		{"uppercase name", "Web", "---\nname: Web\ndescription: Browse\n---\n", "Web", skillInvalidName},
+		{"double hyphen", "web--search", "---\nname: web--search\ndescription: Browse\n---\n", "web--search", skillInvalidName},
+		{"name too long", strings.Repeat("a", 65), "---\nname: " + strings.Repeat("a", 65) + "\ndescription: Browse\n---\n", strings.Repeat("a", 65), skillInvalidName},
+		{"name differs from directory", "web", "---\nname: search\ndescription: Browse\n---\n", "search", skillInvalidNameMismatch},
+		{"missing description", "web", "---\nname: web\n---\n", "web", skillInvalidMissingDescription},
+		{"metadata not a mapping", "web", "---\nname: web\ndescription: Browse\nmetadata: openclaw\n---\n", "web", skillInvalidMetadata},
+		{"metadata of the wrong type", "web", "---\nname: web\ndescription: Browse\nmetadata:\n  openclaw:\n    requires:\n      bins:\n        curl: true\n---\n", "web", skillInvalidMetadata},
+		{"earlier failure wins over metadata", "web", "---\nname: web\nmetadata: openclaw\n---\n", "web", skillInvalidMissingDescription},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skill := parseSkill(tt.dir, []byte(tt.data))
+			if skill.name != tt.wantName {
+				t.Errorf("name = %q, want %q", skill.name, tt.wantName)
+			}
+			if skill.invalidReason != tt.wantReason {
+				t.Errorf("invalidReason = %q, want %q", skill.invalidReason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestParseSkillMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		want     skillMetadata
+		wantErr  bool
+	}{
+		{"no metadata", "", skillMetadata{}, false},
+		{"no openclaw section", "metadata:\n  other: true\n", skillMetadata{}, false},
+		{
+			"openclaw section",
+			"metadata:\n  openclaw:\n    emoji: \"🌐\"\n    always: true\n    os: [darwin, linux]\n    primaryEnv: BRAVE_API_KEY\n    skillKey: brave\n    requires:\n      bins: [curl, jq]\n      anyBins: [chromium, chrome]\n      env: [BRAVE_API_KEY]\n      config: [browser.enabled]\n",
+			withRequires(skillMetadata{
+				Emoji:      "🌐",
+				Always:     true,
+				OS:         stringList{"darwin", "linux"},
+				PrimaryEnv: "BRAVE_API_KEY",
+				SkillKey:   "brave",
+			}, stringList{"curl", "jq"}, stringList{"chromium", "chrome"}, stringList{"BRAVE_API_KEY"}, stringList{"browser.enabled"}),
+			false,
+		},
+		{
+			"JSON-style metadata",
+			`metadata: {"openclaw": {"emoji": "🌐", "os": ["darwin"], "requires": {"bins": ["curl"]}}}` + "\n",
+			withRequires(skillMetadata{Emoji: "🌐", OS: stringList{"darwin"}}, stringList{"curl"}, nil, nil, nil),
+			false,
+		},
+		{"scalar list", "metadata:\n  openclaw:\n    os: linux\n    requires:\n      bins: curl\n", withRequires(skillMetadata{OS: stringList{"linux"}}, stringList{"curl"}, nil, nil, nil), false},
+		{"metadata not a mapping", "metadata: [openclaw]\n", skillMetadata{}, true},
+		{"field of the wrong type", "metadata:\n  openclaw:\n    always: [true]\n", skillMetadata{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var frontmatter skillFrontmatter
+			if err := yaml.Unmarshal([]byte(tt.metadata), &frontmatter); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parseSkillMetadata(frontmatter.Metadata)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSkillMetadata error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSkillMetadata = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// withRequires returns metadata with its requirements set.
+func withRequires(metadata skillMetadata, bins, anyBins, env, config stringList) skillMetadata {
+	metadata.Requires.Bins = bins
+	metadata.Requires.AnyBins = anyBins
+	metadata.Requires.Env = env
+	metadata.Requires.Config = config
+	return metadata
+}
+
+func TestResolveSkillPrecedence(t *testing.T) {
+	type result struct {
+		effective  bool
+		shadowedBy string
+	}
+
+	tests := []struct {
+		name   string
+		skills []skillInfo
+		want   []result
+	}{
+		{
+			"workspace over user over system over legacy",
+			[]skillInfo{
+				{name: "web", source: skillSourceLegacy},
+				{name: "web", source: skillSourceSystem},
+				{name: "web", source: skillSourceUser},
+				{name: "web", source: skillSourceWorkspace},
+			},
+			[]result{
+				{false, skillSourceWorkspace},
+				{false, skillSourceWorkspace},
+				{false, skillSourceWorkspace},
+				{true, ""},
+			},
+		},
+		{
+			"user over system",
+			[]skillInfo{
+				{name: "web", source: skillSourceSystem},
+				{name: "web", source: skillSourceUser},
+			},
+			[]result{{false, skillSourceUser}, {true, ""}},
+		},
+		{
+			"first copy wins within a source",
+			[]skillInfo{
+				{name: "web", source: skillSourceWorkspace, dir: "a-web"},
+				{name: "web", source: skillSourceWorkspace, dir: "b-web"},
+			},
+			[]result{{true, ""}, {false, skillSourceWorkspace}},
+		},
+		{
+			"different names do not shadow",
+			[]skillInfo{
+				{name: "web", source: skillSourceSystem},
+				{name: "search", source: skillSourceWorkspace},
+			},
+			[]result{{true, ""}, {true, ""}},
+		},
+		{
+			"previous results are reset",
+			[]skillInfo{
+				{name: "web", source: skillSourceUser, effective: true},
+				{name: "web", source: skillSourceWorkspace, shadowedBy: skillSourceUser},
+			},
+			[]result{{false, skillSourceWorkspace}, {true, ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolveSkillPrecedence(tt.skills)
+
+			got := make([]result, len(tt.skills))
+			for i, skill := range tt.skills {
+				got[i] = result{skill.effective, skill.shadowedBy}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveSkillPrecedence = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package collector
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/tiktoken-go/tokenizer"
+)
+
+// Encoding used for models without a more specific mapping. Anthropic and
+// most other providers do not publish their tokenizers, so counts for their
+// models are estimates.
+const defaultTokenizerEncoding = "cl100k_base"
+
+// Encodings embedded in the exporter
+var tokenizerEncodings = map[string]bool{
+	"r50k_base":   true,
+	"p50k_base":   true,
+	"p50k_edit":   true,
+	"cl100k_base": true,
+	"o200k_base":  true,
+}
+
+// defaultModelEncodings maps model prefixes (provider/model) to encodings.
+var defaultModelEncodings = map[string]string{
+	"openai/gpt-5":       "o200k_base",
+	"openai/gpt-4.1":     "o200k_base",
+	"openai/gpt-4o":      "o200k_base",
+	"openai/o1":          "o200k_base",
+	"openai/o3":          "o200k_base",
+	"openai/o4":          "o200k_base",
+	"openai/gpt-4":       "cl100k_base",
+	"openai/gpt-3.5":     "cl100k_base",
+	"openai-codex/gpt-5": "o200k_base",
+}
+
+// TokenizerConfig selects the tokenizer encoding per model family.
+type TokenizerConfig struct {
+	// DefaultEncoding applies to models matching no prefix (default: cl100k_base)
+	DefaultEncoding string `yaml:"default_encoding"`
+	// Encodings maps model prefixes such as "anthropic/" or "openai/gpt-4o"
+	// to encodings, extending the built-in mapping
+	Encodings map[string]string `yaml:"encodings"`
+}
+
+// Tokenizer counts tokens of workspace files with an embedded BPE encoding
+// chosen by the agent's model. It is safe for concurrent use.
+type Tokenizer struct {
+	defaultEncoding string
+	// prefixes are sorted longest first so the most specific match wins
+	prefixes  []string
+	encodings map[string]string
+	cache     *fileCache[tokenCacheKey, float64]
+
+	mu     sync.Mutex
+	codecs map[string]tokenizer.Codec
+}
+
+type tokenCacheKey struct {
+	path     string
+	encoding string
+	limit    int
+}
+
+// NewTokenizer creates a Tokenizer from cfg, validating its encodings.
+func NewTokenizer(cfg TokenizerConfig) (*Tokenizer, error) {
+	t := &Tokenizer{
+		defaultEncoding: defaultTokenizerEncoding,
+		encodings:       make(map[string]string),
+		codecs:          make(map[string]tokenizer.Codec),
+		cache:           newFileCache[tokenCacheKey, float64](),
+	}
+
+	if cfg.DefaultEncoding != "" {
+		if !tokenizerEncodings[cfg.DefaultEncoding] {
+			return nil, fmt.Errorf("unsupported tokenizer encoding %q", cfg.DefaultEncoding)
+		}
+		t.defaultEncoding = cfg.DefaultEncoding
+	}
+
+	for prefix, encoding := range defaultModelEncodings {
+		t.encodings[prefix] = encoding
+	}
+	for prefix, encoding := range cfg.Encodings {
+		if !tokenizerEncodings[encoding] {
+			return nil, fmt.Errorf("unsupported tokenizer encoding %q for %q", encoding, prefix)
+		}
+		t.encodings[strings.ToLower(prefix)] = encoding
+	}
+
+	for prefix := range t.encodings {
+		t.prefixes = append(t.prefixes, prefix)
+	}
+	sort.Slice(t.prefixes, func(i, j int) bool {
+		if len(t.prefixes[i]) != len(t.prefixes[j]) {
+			return len(t.prefixes[i]) > len(t.prefixes[j])
+		}
+		return t.prefixes[i] < t.prefixes[j]
+	})
+
+	return t, nil
+}
+
+// encodingFor returns the encoding used for a model such as
+// "anthropic/claude-opus-4".
+func (t *Tokenizer) encodingFor(model string) string {
+	model = strings.ToLower(model)
+	for _, prefix := range t.prefixes {
+		if strings.HasPrefix(model, prefix) {
+			return t.encodings[prefix]
+		}
+	}
+
+	return t.defaultEncoding
+}
+
+// countFile returns the number of tokens in the first limit characters of
+// the file at path, or the whole file if limit is not positive. Counts are
+// cached until the file's size or modification time changes.
+func (t *Tokenizer) countFile(path string, info os.FileInfo, encoding string, limit int) (float64, error) {
+	key := tokenCacheKey{path: path, encoding: encoding, limit: limit}
+	if tokens, ok := t.cache.get(key, info); ok {
+		return tokens, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.countData(path, info, data, encoding, limit)
+}
+
+// countData is countFile for a file whose content data the caller has
+// already read.
+func (t *Tokenizer) countData(path string, info os.FileInfo, data []byte, encoding string, limit int) (float64, error) {
+	key := tokenCacheKey{path: path, encoding: encoding, limit: limit}
+	if tokens, ok := t.cache.get(key, info); ok {
+		return tokens, nil
+	}
+
+	text := string(data)
+	if limit > 0 && utf8.RuneCountInString(text) > limit {
+		text = string([]rune(text)[:limit])
+	}
+
+	tokens, err := t.count(text, encoding)
+	if err != nil {
+		return 0, err
+	}
+
+	t.cache.put(key, info, tokens)
+
+	return tokens, nil
+}
+
+// prune drops the cached counts of files not seen since the previous prune.
+func (t *Tokenizer) prune() {
+	t.cache.prune()
+}
+
+// count returns the number of tokens in text.
+func (t *Tokenizer) count(text, encoding string) (float64, error) {
+	codec, err := t.codec(encoding)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := codec.Count(text)
+	return float64(count), err
+}
+
+// codec returns the codec of an encoding, loading its vocabulary on first use.
+func (t *Tokenizer) codec(encoding string) (tokenizer.Codec, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if codec, ok := t.codecs[encoding]; ok {
+		return codec, nil
+	}
+
+	codec, err := tokenizer.Get(tokenizer.Encoding(encoding))
+	if err != nil {
+		return nil, fmt.Errorf("tokenizer encoding %q: %w", encoding, err)
+	}
+	t.codecs[encoding] = codec
+
+	return codec, nil
+}
@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTokenizerCachePrune(t *testing.T) {
+	tok, err := NewTokenizer(TokenizerConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	kept := filepath.Join(dir, "AGENTS.md")
+	deleted := filepath.Join(dir, "old.md")
+	for _, path := range []string{kept, deleted} {
+		if err := os.WriteFile(path, []byte("hello world"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count := func(path string) {
+		t.Helper()
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tok.countFile(path, info, defaultTokenizerEncoding, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// First scan sees both files
+	count(kept)
+	count(deleted)
+	tok.prune()
+	if len(tok.cache.entries) != 2 {
+		t.Fatalf("cache has %d entries after the first scan, want 2", len(tok.cache.entries))
+	}
+
+	// Second scan only sees the file that still exists
+	count(kept)
+	tok.prune()
+	if len(tok.cache.entries) != 1 {
+		t.Fatalf("cache has %d entries after the second scan, want 1", len(tok.cache.entries))
+	}
+	if _, ok := tok.cache.entries[tokenCacheKey{path: kept, encoding: defaultTokenizerEncoding}]; !ok {
+		t.Errorf("cache lost the entry of %s", kept)
+	}
+
+	// A scan without token counts empties the cache
+	tok.prune()
+	if len(tok.cache.entries) != 0 {
+		t.Errorf("cache has %d entries after an empty scan, want 0", len(tok.cache.entries))
+	}
+}
+
+func TestTokenizerCountDataTruncates(t *testing.T) {
+	tok, err := NewTokenizer(TokenizerConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "SOUL.md")
+	data := []byte("one two three four five six seven eight nine ten")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full, err := tok.countData(path, info, data, defaultTokenizerEncoding, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	truncated, err := tok.countData(path, info, data, defaultTokenizerEncoding, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if truncated >= full || truncated == 0 {
+		t.Errorf("countData with limit 7 = %v, want fewer than the %v tokens of the whole file", truncated, full)
+	}
+
+	fromFile, err := tok.countFile(path, info, defaultTokenizerEncoding, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromFile != full {
+		t.Errorf("countFile = %v, countData = %v, want equal", fromFile, full)
+	}
+}
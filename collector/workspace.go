@@ -0,0 +1,90 @@
+package collector
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Workspace is an agent workspace directory monitored by OpenclawCollector.
+type Workspace struct {
+	Agent string `yaml:"agent"`
+	Dir   string `yaml:"dir"`
+}
+
+// ParseWorkspace parses a workspace flag value of the form "dir" or
+// "agent=dir". The agent is left empty when not given.
+func ParseWorkspace(value string) Workspace {
+	if agent, dir, ok := strings.Cut(value, "="); ok && agent != "" && !strings.ContainsRune(agent, filepath.Separator) {
+		return Workspace{Agent: agent, Dir: expandHome(dir)}
+	}
+
+	return Workspace{Dir: expandHome(value)}
+}
+
+// ResolveWorkspaces fills in missing agent names from the agents discovered
+// in openclawHome and drops duplicate directories, keeping the first entry
+// that names its agent. If no workspaces are given, the workspace of every
+// discovered agent that exists on disk is returned instead. Discovery errors
+// are only returned when no workspaces are given and no agents were found;
+// otherwise they are logged.
+func ResolveWorkspaces(openclawHome string, workspaces []Workspace) ([]Workspace, error) {
+	agents, err := discoverAgents(openclawHome)
+	if err != nil {
+		if len(workspaces) == 0 && len(agents) == 0 {
+			return nil, err
+		}
+		log.Printf("Error discovering agents, continuing with the known workspaces: %v", err)
+	}
+
+	if len(workspaces) == 0 {
+		for _, agent := range agents {
+			if info, err := os.Stat(agent.workspace); err == nil && info.IsDir() {
+				workspaces = append(workspaces, Workspace{Agent: agent.id, Dir: agent.workspace})
+			}
+		}
+		return workspaces, nil
+	}
+
+	agentsByDir := make(map[string][]string)
+	for _, agent := range agents {
+		dir := filepath.Clean(agent.workspace)
+		agentsByDir[dir] = append(agentsByDir[dir], agent.id)
+	}
+
+	index := make(map[string]int)
+	resolved := make([]Workspace, 0, len(workspaces))
+	for _, ws := range workspaces {
+		ws.Dir = filepath.Clean(ws.Dir)
+		if i, ok := index[ws.Dir]; ok {
+			// A later entry may name the agent an earlier one left out
+			if resolved[i].Agent == "" {
+				resolved[i].Agent = ws.Agent
+			}
+			continue
+		}
+		index[ws.Dir] = len(resolved)
+		resolved = append(resolved, ws)
+	}
+
+	for i := range resolved {
+		ws := &resolved[i]
+		if ws.Agent != "" {
+			continue
+		}
+
+		switch ids := agentsByDir[ws.Dir]; len(ids) {
+		case 1:
+			ws.Agent = ids[0]
+		case 0:
+			// Unknown workspace; fall back to the directory name
+			ws.Agent = filepath.Base(ws.Dir)
+		default:
+			log.Printf("Workspace %s is shared by agents %s; set its agent explicitly", ws.Dir, strings.Join(ids, ", "))
+			ws.Agent = filepath.Base(ws.Dir)
+		}
+	}
+
+	return resolved, nil
+}
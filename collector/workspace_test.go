@@ -0,0 +1,98 @@
+package collector
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveWorkspaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string
+		workspaces []Workspace
+		want       []Workspace
+		wantErr    bool
+	}{
+		{
+			name:       "agent from config",
+			config:     `{agents: {list: [{id: "main", workspace: "{{home}}/ws"}]}}`,
+			workspaces: []Workspace{{Dir: "{{home}}/ws/"}},
+			want:       []Workspace{{Agent: "main", Dir: "{{home}}/ws"}},
+		},
+		{
+			name:       "explicit agent on a later duplicate wins over discovery",
+			config:     `{agents: {list: [{id: "ops", workspace: "{{home}}/ws"}]}}`,
+			workspaces: []Workspace{{Dir: "{{home}}/ws/"}, {Agent: "main", Dir: "{{home}}/ws"}},
+			want:       []Workspace{{Agent: "main", Dir: "{{home}}/ws"}},
+		},
+		{
+			name:       "first explicit agent wins",
+			workspaces: []Workspace{{Agent: "main", Dir: "{{home}}/ws"}, {Agent: "ops", Dir: "{{home}}/ws"}},
+			want:       []Workspace{{Agent: "main", Dir: "{{home}}/ws"}},
+		},
+		{
+			name:       "shared workspace is not guessed",
+			config:     `{agents: {list: [{id: "main", workspace: "{{home}}/ws"}, {id: "ops", workspace: "{{home}}/ws"}]}}`,
+			workspaces: []Workspace{{Dir: "{{home}}/ws"}},
+			want:       []Workspace{{Agent: "ws", Dir: "{{home}}/ws"}},
+		},
+		{
+			name:       "unknown workspace uses the directory name",
+			workspaces: []Workspace{{Dir: "{{home}}/other"}},
+			want:       []Workspace{{Agent: "other", Dir: "{{home}}/other"}},
+		},
+		{
+			name:       "broken config with explicit workspaces",
+			config:     `{agents: {list: [`,
+			workspaces: []Workspace{{Dir: "{{home}}/ws"}},
+			want:       []Workspace{{Agent: "ws", Dir: "{{home}}/ws"}},
+		},
+		{
+			name:    "broken config without workspaces",
+			config:  `{agents: {list: [`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("OPENCLAW_CONFIG_PATH", "")
+			expand := func(s string) string {
+				return filepath.FromSlash(strings.ReplaceAll(s, "{{home}}", filepath.ToSlash(home)))
+			}
+
+			if tt.config != "" {
+				if err := os.WriteFile(filepath.Join(home, openclawConfigFile), []byte(expand(tt.config)), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var workspaces []Workspace
+			for _, ws := range tt.workspaces {
+				workspaces = append(workspaces, Workspace{Agent: ws.Agent, Dir: expand(ws.Dir)})
+			}
+			var want []Workspace
+			for _, ws := range tt.want {
+				want = append(want, Workspace{Agent: ws.Agent, Dir: expand(ws.Dir)})
+			}
+
+			got, err := ResolveWorkspaces(home, workspaces)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ResolveWorkspaces() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ResolveWorkspaces() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -6,23 +6,49 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/JetSquirrel/openclaw_exporter/collector"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// Set at build time via -ldflags "-X main.version=... -X main.commit=..."
+var (
+	version = "dev"
+	commit  = "unknown"
+)
+
+// stringSlice is a flag.Value that collects repeated flag values.
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
 func main() {
+	var openclawDirs stringSlice
+	flag.Var(&openclawDirs, "openclaw.dir", "Path to an openclaw workspace directory, optionally as agent=path (repeatable; default: $OPENCLAW_DIR or discovered from openclaw.json)")
+
 	var (
 		listenAddr   = flag.String("web.listen-address", ":9101", "Address to listen on for web interface and telemetry")
 		metricsPath  = flag.String("web.telemetry-path", "/metrics", "Path under which to expose metrics")
-		openclawDir  = flag.String("openclaw.dir", os.Getenv("OPENCLAW_DIR"), "Path to openclaw workspace directory")
 		openclawHome = flag.String("openclaw.home", os.Getenv("OPENCLAW_HOME"), "Path to openclaw home directory (default: ~/.openclaw)")
+		configFile   = flag.String("config.file", "", "Path to exporter configuration file (YAML)")
+		timezone     = flag.String("activity.timezone", "Local", "IANA timezone used to bucket activity metrics by hour and weekday and to date daily memory files")
+		memoryWindow = flag.Int("memory.window-days", 7, "Number of days, ending today, checked for missing daily memory files")
 	)
 	flag.Parse()
 
-	if *openclawDir == "" {
-		log.Fatal("openclaw.dir must be specified via flag or OPENCLAW_DIR environment variable")
+	location, err := time.LoadLocation(*timezone)
+	if err != nil {
+		log.Fatalf("invalid activity.timezone %q: %v", *timezone, err)
 	}
 
 	// Default openclaw home to ~/.openclaw if not specified
@@ -31,16 +57,73 @@ func main() {
 		openclawHomePath = os.Getenv("HOME") + "/.openclaw"
 	}
 
+	if len(openclawDirs) == 0 && os.Getenv("OPENCLAW_DIR") != "" {
+		openclawDirs = append(openclawDirs, os.Getenv("OPENCLAW_DIR"))
+	}
+
+	var workspaces []collector.Workspace
+	for _, dir := range openclawDirs {
+		workspaces = append(workspaces, collector.ParseWorkspace(dir))
+	}
+
+	exporterConfig := &collector.ExporterConfig{}
+	if *configFile != "" {
+		exporterConfig, err = collector.LoadExporterConfig(*configFile)
+		if err != nil {
+			log.Fatalf("Error loading config file: %v", err)
+		}
+		workspaces = append(workspaces, exporterConfig.Workspaces...)
+	}
+
+	tokenizer, err := collector.NewTokenizer(exporterConfig.Tokenizer)
+	if err != nil {
+		log.Fatalf("Error creating tokenizer: %v", err)
+	}
+
+	secretScanner, err := collector.NewSecretScanner(exporterConfig.Secrets)
+	if err != nil {
+		log.Fatalf("Error loading config file: invalid secret pattern: %v", err)
+	}
+
+	// Fall back to the agent workspaces configured in openclaw.json
+	workspaces, err = collector.ResolveWorkspaces(openclawHomePath, workspaces)
+	if err != nil {
+		log.Fatalf("Error discovering workspaces: %v", err)
+	}
+	if len(workspaces) == 0 {
+		log.Fatal("no workspaces found: specify -openclaw.dir, OPENCLAW_DIR or workspaces in -config.file")
+	}
+
 	registry := prometheus.NewRegistry()
 
 	// Register workspace collector
-	openclawCollector := collector.NewOpenclawCollector(*openclawDir)
+	openclawCollector := collector.NewOpenclawCollector(workspaces, openclawHomePath, collector.OpenclawOptions{
+		Location:         location,
+		MemoryWindowDays: *memoryWindow,
+		Tokenizer:        tokenizer,
+		FileRules:        exporterConfig.Files,
+		Secrets:          secretScanner,
+	})
 	registry.MustRegister(openclawCollector, openclawCollector.LatencyCollector())
 
 	// Register session collector
-	sessionCollector := collector.NewSessionCollector(openclawHomePath)
+	sessionCollector := collector.NewSessionCollector(openclawHomePath, location, openclawCollector)
 	registry.MustRegister(sessionCollector)
 
+	// Register cron collector
+	cronCollector := collector.NewCronCollector(openclawHomePath)
+	registry.MustRegister(cronCollector)
+
+	// Register config collector
+	configCollector := collector.NewConfigCollector(openclawHomePath)
+	registry.MustRegister(configCollector)
+
+	// Register security collector
+	securityCollector := collector.NewSecurityCollector(openclawHomePath)
+	registry.MustRegister(securityCollector)
+
+	registry.MustRegister(collector.NewBuildInfoCollector(version, commit))
+
 	http.Handle(*metricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, `<html>
@@ -52,8 +135,11 @@ func main() {
 </html>`, *metricsPath)
 	})
 
-	log.Printf("Starting openclaw exporter on %s", *listenAddr)
-	log.Printf("Workspace: %s, Home: %s", *openclawDir, openclawHomePath)
+	log.Printf("Starting openclaw exporter %s (commit %s) on %s", version, commit, *listenAddr)
+	for _, ws := range workspaces {
+		log.Printf("Workspace: %s (agent %s)", ws.Dir, ws.Agent)
+	}
+	log.Printf("Home: %s", openclawHomePath)
 	if err := http.ListenAndServe(*listenAddr, nil); err != nil {
 		log.Fatal(err)
 	}